@@ -0,0 +1,281 @@
+/*
+Package amend applies amendments to Assembly resolutions.
+
+An amendment consists of instructions, each beginning on a new line,
+that strike, insert, or replace the clauses of a resolution:
+
+	Strike the third Resolved clause.
+	Strike the first Whereas clause and insert in lieu thereof:
+	WHEREAS the Required Quorum (hereinafter Quorum) is four (4);
+	Insert after the second Resolved clause:
+	BE IT FURTHER RESOLVED that the Secretary shall publish Quorum; and
+	Insert before the first Whereas clause:
+	...
+
+Clauses are identified by ordinal and kind, and each instruction refers to the clauses
+of the resolution as amended by the instructions that precede it.
+The text to be inserted consists of the lines following the colon, up to the next instruction,
+and may contain any number of clauses. Any text preceding the first instruction,
+such as the amendment's title, is disregarded.
+
+Each clause of the resolution to be amended must begin on its own line.
+A clause extends from the beginning of its line, including any text such as "BE IT FURTHER",
+through the line preceding the next clause, excluding trailing blank lines.
+*/
+package amend
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+	"github.com/dkmccandless/assembly/token"
+)
+
+// action is the kind of change an instruction makes.
+type action int
+
+const (
+	strike action = iota
+	replace
+	insertBefore
+	insertAfter
+)
+
+// An instruction is a single change to a resolution.
+type instruction struct {
+	action action
+
+	// n is the number of the clause among clauses of the same kind, counting from one (1).
+	n   int
+	typ token.Type // token.WHEREAS or token.RESOLVED
+
+	// text is the text to be inserted, ending with a newline.
+	text string
+
+	// line is the line of the amendment on which the instruction begins.
+	line int
+}
+
+var (
+	instructionStart = regexp.MustCompile(`(?i)^\s*(strike|insert)\b`)
+	strikeRE         = regexp.MustCompile(`(?i)^\s*strike\s+the\s+(\S+)\s+(whereas|resolved)\s+clause(\s+and\s+insert\s+in\s+lieu\s+thereof\s*:|\s*\.?\s*$)(.*)$`)
+	insertRE         = regexp.MustCompile(`(?i)^\s*insert\s+(before|after)\s+the\s+(\S+)\s+(whereas|resolved)\s+clause\s*:(.*)$`)
+)
+
+var (
+	errNoText     = errors.New("no text to insert")
+	errSharedLine = errors.New("clauses share a line")
+)
+
+// Options configure the parsing of a resolution.
+type Options struct {
+	// Dialect holds the conventions by which the resolution's integer literals are written.
+	// The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// Lenient, if set, accepts common nonstandard forms of integers.
+	Lenient bool
+}
+
+// Apply applies the instructions in amendment to the resolution in base.
+// It returns the source text of the amended resolution and the result of parsing it.
+// Resolutions incorporated by reference are found relative to the current directory.
+func Apply(base, amendment string) (string, *ast.Resolution, error) {
+	return ApplyFile("", base, amendment, Options{})
+}
+
+// ApplyFile is like Apply, but for a resolution base read from the file filename, parsed as opts configure.
+// Resolutions incorporated by reference are found relative to the directory containing filename.
+func ApplyFile(filename, base, amendment string, opts Options) (string, *ast.Resolution, error) {
+	instrs, err := parse(amendment)
+	if err != nil {
+		return "", nil, err
+	}
+	src := base
+	for _, in := range instrs {
+		if src, err = in.apply(filename, src, opts); err != nil {
+			return "", nil, fmt.Errorf("amendment line %d: %v", in.line, err)
+		}
+	}
+	p := newParser(filename, src, opts)
+	p.SetReadFile(ioutil.ReadFile)
+	res, err := p.ParseResolution()
+	if err != nil {
+		return src, nil, fmt.Errorf("amended resolution: %v", err)
+	}
+	return src, res, nil
+}
+
+// parse parses the instructions of an amendment.
+func parse(amendment string) ([]instruction, error) {
+	var instrs []instruction
+	var text []string
+	// flush records the text collected for the most recent instruction.
+	flush := func() error {
+		if len(instrs) == 0 {
+			return nil
+		}
+		in := &instrs[len(instrs)-1]
+		t := strings.Trim(strings.Join(text, "\n"), "\n")
+		switch {
+		case in.action == strike && strings.TrimSpace(t) != "":
+			return fmt.Errorf("amendment line %d: text following an instruction to strike", in.line)
+		case in.action != strike && strings.TrimSpace(t) == "":
+			return fmt.Errorf("amendment line %d: %v", in.line, errNoText)
+		case t != "":
+			in.text = t + "\n"
+		}
+		return nil
+	}
+	for i, line := range strings.Split(amendment, "\n") {
+		if !instructionStart.MatchString(line) {
+			if len(instrs) > 0 {
+				text = append(text, line)
+			}
+			continue
+		}
+		if err := flush(); err != nil {
+			return nil, err
+		}
+		in, rest, err := parseInstruction(line)
+		if err != nil {
+			return nil, fmt.Errorf("amendment line %d: %v", i+1, err)
+		}
+		in.line = i + 1
+		instrs = append(instrs, in)
+		text = text[:0]
+		if strings.TrimSpace(rest) != "" {
+			text = append(text, strings.TrimSpace(rest))
+		}
+	}
+	if err := flush(); err != nil {
+		return nil, err
+	}
+	if len(instrs) == 0 {
+		return nil, errors.New("no instructions in amendment")
+	}
+	return instrs, nil
+}
+
+// parseInstruction parses the first line of an instruction
+// and returns any text that follows the instruction on the same line.
+func parseInstruction(line string) (instruction, string, error) {
+	var in instruction
+	var ordinal, kind, rest string
+	if m := strikeRE.FindStringSubmatch(line); m != nil {
+		ordinal, kind, rest = m[1], m[2], m[4]
+		in.action = strike
+		if strings.HasSuffix(strings.TrimSpace(m[3]), ":") {
+			in.action = replace
+		}
+	} else if m := insertRE.FindStringSubmatch(line); m != nil {
+		ordinal, kind, rest = m[2], m[3], m[4]
+		in.action = insertBefore
+		if strings.EqualFold(m[1], "after") {
+			in.action = insertAfter
+		}
+	} else {
+		return in, "", fmt.Errorf("unrecognized instruction %q", strings.TrimSpace(line))
+	}
+	n, ok := parseOrdinal(ordinal)
+	if !ok {
+		return in, "", fmt.Errorf("unrecognized ordinal %q", ordinal)
+	}
+	in.n = n
+	in.typ = token.Lookup(kind)
+	return in, rest, nil
+}
+
+// newParser returns a Parser for the resolution in src, read from the file filename, configured by opts.
+func newParser(filename, src string, opts Options) *parser.Parser {
+	p := parser.NewFile(filename, lexer.New(src))
+	p.SetDialect(opts.Dialect)
+	p.SetLenient(opts.Lenient)
+	return p
+}
+
+// apply applies in to the resolution in src, read from the file filename, and returns the amended source text.
+func (in instruction) apply(filename, src string, opts Options) (string, error) {
+	p := newParser(filename, src, opts)
+	p.ParseResolution()
+	spans, err := spans(src, p.Clauses())
+	if err != nil {
+		return "", err
+	}
+	var span *span
+	for i := range spans {
+		if spans[i].clause.Token.Typ == in.typ && spans[i].clause.N == in.n {
+			span = &spans[i]
+			break
+		}
+	}
+	if span == nil {
+		return "", fmt.Errorf("no %v %v clause", ordinalName(in.n), kindName(in.typ))
+	}
+	switch in.action {
+	case strike:
+		return src[:span.start] + src[span.end:], nil
+	case replace:
+		return src[:span.start] + in.text + src[span.end:], nil
+	case insertBefore:
+		return src[:span.start] + in.text + src[span.start:], nil
+	default:
+		if !strings.HasSuffix(src[:span.end], "\n") {
+			// The clause ends the source text without a final newline.
+			return src + "\n" + in.text, nil
+		}
+		return src[:span.end] + in.text + src[span.end:], nil
+	}
+}
+
+// A span is the extent of a clause in the source text of a resolution.
+type span struct {
+	clause     parser.Clause
+	start, end int
+}
+
+// spans returns the extents in src of clauses, which were parsed from src.
+func spans(src string, clauses []parser.Clause) ([]span, error) {
+	s := make([]span, len(clauses))
+	for i, c := range clauses {
+		s[i] = span{clause: c, start: lineStart(src, c.Pos.Offset)}
+		if i > 0 && s[i].start <= s[i-1].start {
+			return nil, fmt.Errorf("%v and %v: %v", clauses[i-1], c, errSharedLine)
+		}
+	}
+	for i := range s {
+		end := len(src)
+		if i+1 < len(s) {
+			end = s[i+1].start
+		}
+		// Exclude trailing blank lines, but retain the clause's final newline.
+		trimmed := len(strings.TrimRight(src[s[i].start:end], " \t\r\n")) + s[i].start
+		if j := strings.IndexByte(src[trimmed:end], '\n'); j >= 0 {
+			trimmed += j + 1
+		} else {
+			trimmed = end
+		}
+		s[i].end = trimmed
+	}
+	return s, nil
+}
+
+// lineStart returns the offset of the beginning of the line containing offset in src.
+func lineStart(src string, offset int) int {
+	return strings.LastIndexByte(src[:offset], '\n') + 1
+}
+
+// kindName returns the name of the kind of clause that begins with a token of type typ.
+func kindName(typ token.Type) string {
+	if typ == token.WHEREAS {
+		return "Whereas"
+	}
+	return "Resolved"
+}
@@ -0,0 +1,151 @@
+package amend
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+const base = `A Resolution Concerning the Quorum
+
+WHEREAS the Required Quorum (hereinafter Quorum) is three (3);
+WHEREAS the Members Present (hereinafter Members) is four (4): now, therefore,
+
+BE IT RESOLVED that if Members exceeds Quorum, the Secretary shall publish "A quorum is present."; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Members; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Quorum.
+`
+
+func TestApply(t *testing.T) {
+	for _, test := range []struct {
+		amendment, want string
+	}{
+		{
+			`An Amendment Concerning the Quorum
+
+Strike the first Whereas clause and insert in lieu thereof:
+WHEREAS the Required Quorum (hereinafter Quorum) is five (5);
+`,
+			strings.Replace(base, "is three (3)", "is five (5)", 1),
+		},
+		{
+			"Strike the second Resolved clause.",
+			strings.Replace(base, "BE IT FURTHER RESOLVED that the Secretary shall publish Members; and\n", "", 1),
+		},
+		{
+			"Strike the second Whereas clause and insert in lieu thereof: WHEREAS the Members Present (hereinafter Members) is two (2): now, therefore,",
+			strings.Replace(base, "is four (4)", "is two (2)", 1),
+		},
+		{
+			`Insert after the third Resolved clause:
+BE IT FURTHER RESOLVED that the Secretary shall publish "Adjourned.".
+Insert before the first Resolved clause:
+
+BE IT RESOLVED that the Secretary shall publish "Called to order.";
+
+Strike the THIRD Resolved clause.`,
+			`A Resolution Concerning the Quorum
+
+WHEREAS the Required Quorum (hereinafter Quorum) is three (3);
+WHEREAS the Members Present (hereinafter Members) is four (4): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish "Called to order.";
+BE IT RESOLVED that if Members exceeds Quorum, the Secretary shall publish "A quorum is present."; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Quorum.
+BE IT FURTHER RESOLVED that the Secretary shall publish "Adjourned.".
+`,
+		},
+	} {
+		got, res, err := Apply(base, test.amendment)
+		if err != nil {
+			t.Errorf("Apply(%q): unexpected error: %v", test.amendment, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("Apply(%q): got\n%v\nwant\n%v", test.amendment, got, test.want)
+		}
+		if res == nil {
+			t.Errorf("Apply(%q): got nil Resolution", test.amendment)
+		}
+	}
+}
+
+func TestApplyError(t *testing.T) {
+	for _, test := range []struct {
+		amendment, want string
+	}{
+		{"An amendment without instructions", "no instructions in amendment"},
+		{"Strike the fourth Resolved clause.", "amendment line 1: no fourth Resolved clause"},
+		{"Strike the umpteenth Resolved clause.", `amendment line 1: unrecognized ordinal "umpteenth"`},
+		{"Strike the first Whereas clause.\nWHEREAS the Quorum is moot;", "amendment line 1: text following an instruction to strike"},
+		{"Insert after the first Whereas clause:\n", "amendment line 1: no text to insert"},
+		{"Strike everything.", `amendment line 1: unrecognized instruction "Strike everything."`},
+		{"Strike the first Whereas clause.", "amended resolution: 5:40: Quorum undeclared (and 1 more errors)"},
+	} {
+		if _, _, err := Apply(base, test.amendment); err == nil || err.Error() != test.want {
+			t.Errorf("Apply(%q): got error %v, want %v", test.amendment, err, test.want)
+		}
+	}
+	if _, _, err := Apply("title whereas (hereinafter Quorum) is one (1) resolved publish Quorum", "Strike the first Resolved clause."); err == nil || !strings.Contains(err.Error(), "clauses share a line") {
+		t.Errorf("Apply: got error %v, want clauses share a line", err)
+	}
+}
+
+func TestApplyFile(t *testing.T) {
+	const src = `A Resolution Concerning the Quorum
+
+WHEREAS the resolution "definitions.asm" is incorporated by reference: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Quorum.
+`
+	const amendment = `Insert after the first Resolved clause:
+BE IT FURTHER RESOLVED that the Secretary shall publish "Adjourned.".`
+	want := src + "BE IT FURTHER RESOLVED that the Secretary shall publish \"Adjourned.\".\n"
+	got, res, err := ApplyFile("testdata/quorum.asm", src, amendment, Options{})
+	if err != nil || got != want || res == nil {
+		t.Errorf("ApplyFile: got\n%v\n%v, %v; want\n%v", got, res, err, want)
+	}
+
+	// Without the file name, definitions.asm is sought in the current directory.
+	if _, _, err := Apply(src, amendment); err == nil {
+		t.Error("Apply: got no error, want incorporation error")
+	}
+}
+
+func TestApplyFileOptions(t *testing.T) {
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is one hundred and one (101): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Count.
+`
+	const amendment = `Insert after the first Resolved clause:
+BE IT FURTHER RESOLVED that the Secretary shall publish a dozen.`
+	if _, _, err := ApplyFile("", src, amendment, Options{}); err == nil {
+		t.Error("ApplyFile: got no error in American usage")
+	}
+	if _, res, err := ApplyFile("", src, amendment, Options{Dialect: object.British, Lenient: true}); err != nil || res == nil {
+		t.Errorf("ApplyFile: got %v, %v; want no error", res, err)
+	}
+}
+
+func TestParseOrdinal(t *testing.T) {
+	for s, want := range map[string]int{
+		"first":        1,
+		"Third":        3,
+		"twelfth":      12,
+		"twentieth":    20,
+		"twenty-first": 21,
+		"ninety-ninth": 99,
+	} {
+		if n, ok := parseOrdinal(s); !ok || n != want {
+			t.Errorf("parseOrdinal(%v): got %v, %v; want %v", s, n, ok, want)
+		}
+	}
+	for _, s := range []string{"zeroth", "twenty", "hundredth", "twenty-tenth"} {
+		if n, ok := parseOrdinal(s); ok {
+			t.Errorf("parseOrdinal(%v): got %v, want failure", s, n)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package amend
+
+import (
+	"strings"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+// parseOrdinal parses an ordinal word from "first" (1) through "ninety-ninth" (99).
+func parseOrdinal(s string) (int, bool) {
+	s = strings.ToLower(s)
+	for n := 1; n < 100; n++ {
+		if ordinalName(n) == s {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// ordinalName returns the ordinal word for n, such as "twenty-first",
+// which is written by object.Ordinal before the parenthesized numeral.
+func ordinalName(n int) string {
+	s := object.Ordinal(int64(n))
+	return s[:strings.Index(s, " (")]
+}
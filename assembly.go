@@ -0,0 +1,255 @@
+/*
+Package assembly embeds the Assembly interpreter in Go programs.
+
+Run parses and carries out a resolution in a single call.
+Host programs may supply Go values as predeclared variables,
+which resolutions may use and assume as though they had been declared in a Whereas clause.
+
+An Interpreter additionally lets host programs register Go functions as built-ins,
+which resolutions call by name:
+
+	the finding of the Audit upon Ledger and Year
+*/
+package assembly
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/eval"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/minutes"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+	"github.com/dkmccandless/assembly/token"
+)
+
+// Options configure the carrying out of a resolution.
+type Options struct {
+	// Filename, if set, names the file from which the resolution was read.
+	// Resolutions that it incorporates by reference are found relative to the directory containing it.
+	Filename string
+
+	// Incorporate, if set, permits the resolution to incorporate other resolutions by reference.
+	// Otherwise incorporation by reference is an error.
+	Incorporate bool
+
+	// ReadFile reads the file of each resolution incorporated by reference.
+	// If ReadFile is nil, ioutil.ReadFile is used. It is not called unless Incorporate is set.
+	ReadFile func(name string) ([]byte, error)
+
+	// Stdout receives the output published by the Secretary. If Stdout is nil, it is discarded.
+	Stdout io.Writer
+
+	// Clerk and Treasurer receive the output entered into the record by the Clerk
+	// and reported by the Treasurer. If either is nil, its output is sent to Stdout.
+	Clerk, Treasurer io.Writer
+
+	// Trace, if non-nil, receives the minutes of the proceedings.
+	Trace io.Writer
+
+	// Vars holds values provided by the host, keyed by variable name,
+	// which are declared before the resolution's first Whereas clause.
+	// Each name must be a valid identifier, and each value must be an object.Object,
+	// a string, or a Go integer whose value fits in sixty-four (64) signed bits.
+	// It is not an error for a resolution not to use a host-provided variable.
+	Vars map[string]interface{}
+
+	// Dialect holds the conventions by which the resolution writes integers in words,
+	// both in its integer literals and in what it publishes. The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// Lenient, if set, accepts common nonstandard forms of integers, such as "twenty one", "a dozen",
+	// and a cardinal without its parenthesized numeral, and reports them in Result.Warnings instead of as errors.
+	Lenient bool
+}
+
+// Result holds the outcome of carrying out a resolution.
+type Result struct {
+	// Env holds the resolution's variables and their final values, including those provided by the host.
+	Env *object.Environment
+
+	// Warnings describes the nonstandard constructs accepted in lenient mode, each with a suggested rewrite.
+	Warnings []parser.Warning
+}
+
+// A RuntimeError is an error that halts a resolution while it is being carried out.
+type RuntimeError struct {
+	Err *object.Error
+
+	// Pos is the position of the innermost statement in which Err occurred,
+	// or the zero Pos if it is not known.
+	Pos token.Pos
+}
+
+// Error implements the error interface.
+func (e *RuntimeError) Error() string { return e.Err.Inspect() }
+
+// An Interpreter carries out resolutions with access to the built-in functions registered with it.
+type Interpreter struct {
+	builtins map[string]*object.Builtin
+}
+
+// New returns an Interpreter with no registered functions.
+func New() *Interpreter {
+	return &Interpreter{builtins: make(map[string]*object.Builtin)}
+}
+
+// Register registers fn as the built-in function name, replacing any function previously registered as name.
+// Resolutions call it with an expression such as "the finding of the Audit upon Ledger and Year",
+// which passes the values of Ledger and Year as args and evaluates to fn's result.
+// If fn returns an *object.Error, the resolution is halted.
+// Run reports an error if name is not a valid identifier.
+func (in *Interpreter) Register(name string, fn object.BuiltinFunction) {
+	in.builtins[name] = &object.Builtin{Name: name, Fn: fn}
+}
+
+// Run parses the resolution in src and carries it out using an Interpreter with no registered functions.
+// See Interpreter.Run.
+func Run(ctx context.Context, src string, opts Options) (Result, error) {
+	return New().Run(ctx, src, opts)
+}
+
+// Run parses the resolution in src and carries it out.
+//
+// If src cannot be parsed, Run returns the parser's errors.
+// If the resolution is halted by a runtime error, Run returns a *RuntimeError.
+// If ctx is done before the resolution finishes, Run stops before the next statement and returns ctx.Err().
+// In each case Result holds the variables as they stood when the resolution stopped, if it began.
+func (in *Interpreter) Run(ctx context.Context, src string, opts Options) (Result, error) {
+	env := object.NewEnvironment()
+	p := parser.NewFile(opts.Filename, lexer.New(src))
+	p.SetDialect(opts.Dialect)
+	p.SetLenient(opts.Lenient)
+	if opts.Incorporate {
+		readFile := opts.ReadFile
+		if readFile == nil {
+			readFile = ioutil.ReadFile
+		}
+		p.SetReadFile(readFile)
+	}
+	for _, name := range sortedNames(in.builtins) {
+		if !token.IsIdentifier(name) {
+			return Result{}, fmt.Errorf("function %v: invalid identifier", name)
+		}
+		p.DeclareFunc(name)
+	}
+	vars := make([]string, 0, len(opts.Vars))
+	for name := range opts.Vars {
+		vars = append(vars, name)
+	}
+	sort.Strings(vars)
+	for _, name := range vars {
+		if !token.IsIdentifier(name) {
+			return Result{}, fmt.Errorf("variable %v: invalid identifier", name)
+		}
+		obj, err := toObject(opts.Vars[name])
+		if err != nil {
+			return Result{}, fmt.Errorf("variable %v: %v", name, err)
+		}
+		p.Declare(name)
+		env.Set(name, obj)
+	}
+	res, err := p.ParseResolution()
+	if err != nil {
+		return Result{Warnings: p.Warnings()}, err
+	}
+
+	e := &eval.Evaluator{
+		Out:       opts.Stdout,
+		Clerk:     opts.Clerk,
+		Treasurer: opts.Treasurer,
+		Builtins:  in.builtins,
+		Dialect:   opts.Dialect,
+		Before: func(ast.Node, *object.Environment) object.Object {
+			if err := ctx.Err(); err != nil {
+				return &object.Error{Value: err.Error()}
+			}
+			return nil
+		},
+	}
+	if e.Out == nil {
+		e.Out = ioutil.Discard
+	}
+	// Record the position of the innermost statement that produced each new error
+	// as it propagates outward.
+	var (
+		lastErr object.Object
+		errPos  token.Pos
+	)
+	e.After = func(stmt ast.Node, _ *object.Environment, result object.Object) {
+		if err, ok := result.(*object.Error); ok && result != lastErr {
+			lastErr, errPos = err, p.Pos(stmt)
+		}
+	}
+	var rec *minutes.Recorder
+	if opts.Trace != nil {
+		rec = minutes.New(opts.Trace, src, p)
+		rec.Attach(e)
+	}
+	obj := e.Eval(res, env)
+	if rec != nil {
+		rec.Adjourn(obj)
+	}
+	if err := ctx.Err(); err != nil {
+		return Result{Env: env, Warnings: p.Warnings()}, err
+	}
+	if obj, ok := obj.(*object.Error); ok {
+		rerr := &RuntimeError{Err: obj}
+		if obj == lastErr {
+			rerr.Pos = errPos
+		}
+		return Result{Env: env, Warnings: p.Warnings()}, rerr
+	}
+	return Result{Env: env, Warnings: p.Warnings()}, nil
+}
+
+// sortedNames returns the names of builtins in sorted order.
+func sortedNames(builtins map[string]*object.Builtin) []string {
+	names := make([]string, 0, len(builtins))
+	for name := range builtins {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// toObject converts a host-provided value to an object.Object.
+func toObject(v interface{}) (object.Object, error) {
+	switch v := v.(type) {
+	case object.Object:
+		return v, nil
+	case string:
+		return &object.String{Value: v}, nil
+	case int:
+		return &object.Integer{Value: int64(v)}, nil
+	case int8:
+		return &object.Integer{Value: int64(v)}, nil
+	case int16:
+		return &object.Integer{Value: int64(v)}, nil
+	case int32:
+		return &object.Integer{Value: int64(v)}, nil
+	case int64:
+		return &object.Integer{Value: v}, nil
+	case uint8:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint16:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint32:
+		return &object.Integer{Value: int64(v)}, nil
+	case uint:
+		return toObject(uint64(v))
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("%v overflows sixty-four (64) bits", v)
+		}
+		return &object.Integer{Value: int64(v)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
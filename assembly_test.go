@@ -0,0 +1,327 @@
+package assembly
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+const countSrc = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is two (2): now, therefore,
+
+BE IT RESOLVED that Count assume the value sum Count Increment; and
+BE IT FURTHER RESOLVED that the Secretary shall publish the aforesaid Count.`
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	res, err := Run(context.Background(), countSrc, Options{
+		Stdout: &out,
+		Vars:   map[string]interface{}{"Increment": uint8(3), "Unused": "not an error"},
+	})
+	if err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if got, want := out.String(), "five (5)\n"; got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+	obj, ok := res.Env.Get("Count")
+	if !ok {
+		t.Fatal("Run: Count not in environment")
+	}
+	if got, ok := obj.(*object.Integer); !ok || got.Value != 5 {
+		t.Errorf("Run: got Count %v, want five (5)", obj.Inspect())
+	}
+}
+
+func TestOfficers(t *testing.T) {
+	const src = `A Resolution Concerning the Accounts
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish "Dues are payable."; and
+BE IT FURTHER RESOLVED that the Clerk shall enter into the record "Dues assessed."; and
+BE IT FURTHER RESOLVED that the Treasurer shall report Total.`
+	var secretary, clerk, treasurer bytes.Buffer
+	if _, err := Run(context.Background(), src, Options{Stdout: &secretary, Clerk: &clerk, Treasurer: &treasurer}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	for _, test := range []struct {
+		officer   string
+		got, want string
+	}{
+		{"Secretary", secretary.String(), "Dues are payable.\n"},
+		{"Clerk", clerk.String(), "Dues assessed.\n"},
+		{"Treasurer", treasurer.String(), "one hundred (100)\n"},
+	} {
+		if test.got != test.want {
+			t.Errorf("Run: %v got output %q, want %q", test.officer, test.got, test.want)
+		}
+	}
+
+	// Without their own destinations, the Clerk and Treasurer send their output to Stdout.
+	var out bytes.Buffer
+	if _, err := Run(context.Background(), src, Options{Stdout: &out}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if got, want := out.String(), "Dues are payable.\nDues assessed.\none hundred (100)\n"; got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+}
+
+func TestRunDialect(t *testing.T) {
+	const src = `A Resolution Concerning the Treasury
+
+WHEREAS the Reserve of the Treasury (hereinafter Reserve) is two milliard one hundred and five (2,000,000,105): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Reserve; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Reserve as an ordinal.`
+	var out bytes.Buffer
+	if _, err := Run(context.Background(), src, Options{Stdout: &out, Dialect: object.BritishLongScale}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	want := "two milliard one hundred and five (2,000,000,105)\n" +
+		"two milliard one hundred and fifth (2,000,000,105th)\n"
+	if got := out.String(); got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+
+	// The default dialect does not recognize the long scale.
+	if _, err := Run(context.Background(), src, Options{Stdout: &out}); err == nil {
+		t.Error("Run: American dialect: got no error, want invalid cardinal")
+	}
+}
+
+func TestRunLenient(t *testing.T) {
+	const src = `A Resolution Concerning the Eggs
+
+WHEREAS the Count of Eggs (hereinafter Eggs) is a dozen: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Eggs.`
+	var out bytes.Buffer
+	res, err := Run(context.Background(), src, Options{Stdout: &out, Lenient: true})
+	if err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if got, want := out.String(), "twelve (12)\n"; got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+	want := []Diagnostic{{
+		Severity:   "warning",
+		Code:       "nonstandard",
+		Message:    `nonstandard integer: "dozen" in place of "twelve", missing numeral`,
+		Suggestion: "twelve (12)",
+		File:       "eggs.asm",
+		Line:       3,
+		Column:     49,
+		EndLine:    3,
+		EndColumn:  56,
+	}}
+	if got := WarningDiagnostics("eggs.asm", res.Warnings); !reflect.DeepEqual(got, want) {
+		t.Errorf("WarningDiagnostics: got %+v, want %+v", got, want)
+	}
+
+	// Strict mode is the default.
+	if _, err := Run(context.Background(), src, Options{}); err == nil {
+		t.Error("Run: strict: got no error, want invalid cardinal")
+	}
+}
+
+func TestRunIncorporate(t *testing.T) {
+	const src = `A Resolution Concerning the Quorum
+
+WHEREAS the definitions in "rules/definitions.asm" are incorporated by reference: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Quorum.`
+	files := map[string]string{
+		"rules/definitions.asm": "Definitions\n\nWHEREAS the Quorum (hereinafter Quorum) is three (3).",
+	}
+	readFile := func(name string) ([]byte, error) {
+		if text, ok := files[name]; ok {
+			return []byte(text), nil
+		}
+		return nil, os.ErrNotExist
+	}
+	var out bytes.Buffer
+	if _, err := Run(context.Background(), src, Options{Stdout: &out, Incorporate: true, ReadFile: readFile}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if got, want := out.String(), "three (3)\n"; got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+
+	// Incorporation by reference is not enabled by default.
+	_, err := Run(context.Background(), src, Options{ReadFile: readFile})
+	if diags := Diagnostics("", err); len(diags) == 0 || diags[0].Code != "incorporation-disabled" {
+		t.Errorf("Run: got diagnostics %+v, want incorporation-disabled", diags)
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		vars map[string]interface{}
+		want string
+	}{
+		{"undeclared", nil, "Increment undeclared"},
+		{"invalid identifier", map[string]interface{}{"Increment": 1, "increment": 1}, "variable increment: invalid identifier"},
+		{"keyword", map[string]interface{}{"Increment": 1, "Whereas": 1}, "variable Whereas: invalid identifier"},
+		{"type", map[string]interface{}{"Increment": 1.5}, "variable Increment: unsupported type float64"},
+		{"overflow", map[string]interface{}{"Increment": uint64(1) << 63}, "variable Increment: 9223372036854775808 overflows sixty-four (64) bits"},
+		{"redeclared", map[string]interface{}{"Increment": 1, "Count": 1}, "Count redeclared"},
+	} {
+		_, err := Run(context.Background(), countSrc, Options{Vars: test.vars})
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%v: got error %v, want %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestRuntimeError(t *testing.T) {
+	_, err := Run(context.Background(), countSrc, Options{Vars: map[string]interface{}{"Increment": "one"}})
+	var rerr *RuntimeError
+	if !errors.As(err, &rerr) {
+		t.Fatalf("Run: got error %v, want *RuntimeError", err)
+	}
+}
+
+func TestDiagnostics(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		src  string
+		vars map[string]interface{}
+		want []Diagnostic
+	}{
+		{
+			"parse errors",
+			strings.Replace(countSrc, "Increment", "Step", 1),
+			nil,
+			[]Diagnostic{{"error", "undeclared", "Step undeclared", "count.asm", 5, 54, 0, 0, ""}},
+		},
+		{
+			"runtime error",
+			countSrc,
+			map[string]interface{}{"Increment": "one"},
+			[]Diagnostic{{"error", "non-numeric", "non-numeric one in numeric context", "count.asm", 5, 21, 0, 0, ""}},
+		},
+		{
+			"invalid options",
+			countSrc,
+			map[string]interface{}{"increment": 1},
+			[]Diagnostic{{"error", "invalid-options", "variable increment: invalid identifier", "count.asm", 0, 0, 0, 0, ""}},
+		},
+	} {
+		_, err := Run(context.Background(), test.src, Options{Vars: test.vars})
+		if got := Diagnostics("count.asm", err); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%v: got %+v, want %+v", test.name, got, test.want)
+		}
+	}
+	if got := Diagnostics("count.asm", nil); got != nil {
+		t.Errorf("Diagnostics(nil): got %+v, want nil", got)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var out bytes.Buffer
+	res, err := Run(ctx, countSrc, Options{Stdout: &out, Vars: map[string]interface{}{"Increment": 1}})
+	if err != context.Canceled {
+		t.Errorf("Run: got error %v, want %v", err, context.Canceled)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Run: got output %q, want none", out.String())
+	}
+	if obj, ok := res.Env.Get("Count"); ok {
+		t.Errorf("Run: got Count %v, want no value", obj.Inspect())
+	}
+}
+
+func TestRunTrace(t *testing.T) {
+	var trace bytes.Buffer
+	if _, err := Run(context.Background(), countSrc, Options{Trace: &trace, Vars: map[string]interface{}{"Increment": 1}}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if want := "Count, previously two (2), now stands at three (3)."; !strings.Contains(trace.String(), want) {
+		t.Errorf("Run: got minutes %q, want them to contain %q", trace.String(), want)
+	}
+}
+
+func TestRunTraceDialect(t *testing.T) {
+	const src = `A Resolution Concerning the Treasury
+
+WHEREAS the Reserve of the Treasury (hereinafter Reserve) is two milliard one hundred and five (2,000,000,105): now, therefore,
+
+BE IT RESOLVED that Reserve assume the value Reserve less one hundred and five (105); and
+BE IT FURTHER RESOLVED that the Assembly affirms that Reserve equals one hundred and five (105).`
+	var trace bytes.Buffer
+	_, err := Run(context.Background(), src, Options{Trace: &trace, Dialect: object.BritishLongScale})
+	const failure = "affirmation failed: two milliard (2,000,000,000) does not equal one hundred and five (105)"
+	if err == nil || err.Error() != failure {
+		t.Errorf("Run: got error %v, want %v", err, failure)
+	}
+	for _, want := range []string{
+		"Reserve stands at two milliard one hundred and five (2,000,000,105).",
+		"Reserve, previously two milliard one hundred and five (2,000,000,105), now stands at two milliard (2,000,000,000).",
+		"The proceedings were halted: " + failure,
+	} {
+		if !strings.Contains(trace.String(), want) {
+			t.Errorf("Run: got minutes %q, want them to contain %q", trace.String(), want)
+		}
+	}
+}
+
+func ExampleRun() {
+	src := `A Resolution Concerning Greetings
+
+WHEREAS the Assembly wishes to greet the host: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the aforesaid Greeting.`
+
+	_, err := Run(context.Background(), src, Options{
+		Stdout: os.Stdout,
+		Vars:   map[string]interface{}{"Greeting": "Hello, World!"},
+	})
+	if err != nil {
+		panic(err)
+	}
+	// Output: Hello, World!
+}
+
+func TestRegister(t *testing.T) {
+	src := `A Resolution Concerning the Ledger
+
+WHEREAS the Ledger of Accounts (hereinafter Ledger) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the finding of the Audit upon Ledger and Year.`
+
+	in := New()
+	in.Register("Audit", func(args ...object.Object) object.Object {
+		if len(args) != 2 {
+			return &object.Error{Value: "the Audit requires a ledger and a year"}
+		}
+		return &object.String{Value: args[0].Inspect() + " in " + args[1].Inspect()}
+	})
+	var out bytes.Buffer
+	if _, err := in.Run(context.Background(), src, Options{Stdout: &out, Vars: map[string]interface{}{"Year": 2020}}); err != nil {
+		t.Fatalf("Run: unexpected error: %v", err)
+	}
+	if got, want := out.String(), "one hundred (100) in two thousand twenty (2,020)\n"; got != want {
+		t.Errorf("Run: got output %q, want %q", got, want)
+	}
+
+	if _, err := Run(context.Background(), src, Options{Vars: map[string]interface{}{"Year": 2020}}); err == nil || !strings.Contains(err.Error(), "Audit is not a function") {
+		t.Errorf("Run without Audit: got error %v, want Audit is not a function", err)
+	}
+
+	in.Register("audit", func(args ...object.Object) object.Object { return nil })
+	if _, err := in.Run(context.Background(), src, Options{}); err == nil || err.Error() != "function audit: invalid identifier" {
+		t.Errorf("Run: got error %v, want invalid identifier", err)
+	}
+}
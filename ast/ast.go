@@ -33,8 +33,21 @@ type DeclStmt struct {
 }
 
 func (s *DeclStmt) whStmtNode()    {}
+func (s *DeclStmt) resStmtNode()   {}
 func (s *DeclStmt) String() string { return s.Token.Lit }
 
+// An IncorporationStmt represents the incorporation by reference of the declarations of another resolution.
+type IncorporationStmt struct {
+	Token token.Token // token.INCORPORATED
+	Path  string      // the name of the incorporated file, as written
+
+	// Stmts holds the Whereas statements of the incorporated resolution.
+	Stmts []WhereasStmt
+}
+
+func (s *IncorporationStmt) whStmtNode()    {}
+func (s *IncorporationStmt) String() string { return s.Token.Lit }
+
 // Statements that can occur in Resolved clauses implement the ResolvedStmt interface.
 type ResolvedStmt interface {
 	Node
@@ -60,14 +73,86 @@ type IfStmt struct {
 func (s *IfStmt) resStmtNode()   {}
 func (s *IfStmt) String() string { return s.Token.Lit }
 
+// An AffirmStmt halts the resolution unless the relation between Left and Right holds.
+type AffirmStmt struct {
+	Token       token.Token // token.AFFIRMS
+	Left, Right Expr
+	Relation    token.Token // e.g. token.EQUALS
+}
+
+func (s *AffirmStmt) resStmtNode()   {}
+func (s *AffirmStmt) String() string { return s.Token.Lit }
+
+// The officers of the Assembly, each of whom makes output to a separate destination.
+const (
+	Secretary = "Secretary" // publishes
+	Clerk     = "Clerk"     // enters into the record
+	Treasurer = "Treasurer" // reports
+)
+
+// IsOfficer reports whether name is the name of an officer of the Assembly.
+func IsOfficer(name string) bool { return name == Secretary || name == Clerk || name == Treasurer }
+
 type PublishStmt struct {
-	Token token.Token // token.PUBLISH
-	Value Expr
+	Token   token.Token // token.PUBLISH, token.ENTER, or token.REPORT
+	Officer string      // the officer named in the statement's clause, or "" if none
+	Value   Expr
+	Format  token.Token // token.WORDS, token.FIGURES, token.ORDINAL, or token.ROMAN, or the zero Token if none
 }
 
 func (s *PublishStmt) resStmtNode()   {}
 func (s *PublishStmt) String() string { return s.Token.Lit }
 
+// Responsible returns the officer who makes the output of s:
+// Officer if it is set, or else the officer whose duty is named by s's Token.
+func (s *PublishStmt) Responsible() string {
+	switch {
+	case s.Officer != "":
+		return s.Officer
+	case s.Token.Typ == token.ENTER:
+		return Clerk
+	case s.Token.Typ == token.REPORT:
+		return Treasurer
+	default:
+		return Secretary
+	}
+}
+
+// A CommitteeStmt represents a Committee of the Whole, whose body may declare variables
+// that are visible only within it.
+type CommitteeStmt struct {
+	Token token.Token // token.COMMITTEE
+	Body  []ResolvedStmt
+}
+
+func (s *CommitteeStmt) resStmtNode()   {}
+func (s *CommitteeStmt) String() string { return s.Token.Lit }
+
+// A RecoverStmt carries out Stmt, and if it fails, carries out Fallback instead.
+// If Name is not nil, the error message is bound to Name while Fallback is carried out.
+// A nil Fallback disregards the failure.
+type RecoverStmt struct {
+	Token    token.Token // token.FAIL
+	Stmt     ResolvedStmt
+	Name     *Identifier
+	Fallback ResolvedStmt
+}
+
+func (s *RecoverStmt) resStmtNode()   {}
+func (s *RecoverStmt) String() string { return s.Token.Lit }
+
+// Assignee returns the identifier to which stmt assigns a value, or nil if it does not assign one.
+func Assignee(stmt Node) *Identifier {
+	switch stmt := stmt.(type) {
+	case *DeclStmt:
+		return stmt.Name
+	case *AssumeStmt:
+		return stmt.Name
+	default:
+		return nil
+	}
+}
+
 // Expressions implement the Expr interface.
 type Expr interface {
 	Node
@@ -98,6 +183,16 @@ type StringLiteral struct {
 func (e *StringLiteral) exprNode()      {}
 func (e *StringLiteral) String() string { return e.Value }
 
+// An InterpolatedString is a string literal that refers to variables by name, such as "[Total] members are present".
+// Its value is the concatenation of the values of Parts, each of which is a *StringLiteral or an *Identifier.
+type InterpolatedString struct {
+	Token token.Token // token.STRING
+	Parts []Expr
+}
+
+func (e *InterpolatedString) exprNode()      {}
+func (e *InterpolatedString) String() string { return e.Token.Lit }
+
 type InfixExpr struct {
 	Token       token.Token // e.g. token.LESS
 	Left, Right Expr
@@ -124,6 +219,25 @@ func (e *BinaryPrefixExpr) String() string {
 	return fmt.Sprintf("%v %v %v", e.Token.Lit, e.First, e.Second)
 }
 
+type CallExpr struct {
+	Token    token.Token // token.FINDING
+	Function *Identifier
+	Args     []Expr
+}
+
+func (e *CallExpr) exprNode() {}
+func (e *CallExpr) String() string {
+	s := fmt.Sprintf("%v %v", e.Token.Lit, e.Function)
+	for i, arg := range e.Args {
+		if i == 0 {
+			s += fmt.Sprintf(" upon %v", arg)
+		} else {
+			s += fmt.Sprintf(" and %v", arg)
+		}
+	}
+	return s
+}
+
 type PostfixExpr struct {
 	Token token.Token // e.g. token.SQUARED
 	Left  Expr
@@ -0,0 +1,73 @@
+package ast
+
+// Inspect traverses the AST rooted at node in depth-first order.
+// It calls f(node) for each node it visits; if f returns true,
+// Inspect then visits each of the node's non-nil children.
+func Inspect(node Node, f func(Node) bool) {
+	if node == nil || !f(node) {
+		return
+	}
+	switch n := node.(type) {
+	case *Resolution:
+		for _, s := range n.WhereasStmts {
+			Inspect(s, f)
+		}
+		for _, s := range n.ResolvedStmts {
+			Inspect(s, f)
+		}
+	case *IncorporationStmt:
+		for _, s := range n.Stmts {
+			Inspect(s, f)
+		}
+	case *DeclStmt:
+		inspectIdent(n.Name, f)
+		Inspect(n.Value, f)
+	case *AssumeStmt:
+		inspectIdent(n.Name, f)
+		Inspect(n.Value, f)
+	case *IfStmt:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+		Inspect(n.Consequence, f)
+	case *AffirmStmt:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *PublishStmt:
+		Inspect(n.Value, f)
+	case *RecoverStmt:
+		Inspect(n.Stmt, f)
+		inspectIdent(n.Name, f)
+		Inspect(n.Fallback, f)
+	case *CommitteeStmt:
+		for _, s := range n.Body {
+			Inspect(s, f)
+		}
+	case *InterpolatedString:
+		for _, part := range n.Parts {
+			Inspect(part, f)
+		}
+	case *InfixExpr:
+		Inspect(n.Left, f)
+		Inspect(n.Right, f)
+	case *UnaryPrefixExpr:
+		Inspect(n.Right, f)
+	case *BinaryPrefixExpr:
+		Inspect(n.First, f)
+		Inspect(n.Second, f)
+	case *CallExpr:
+		inspectIdent(n.Function, f)
+		for _, arg := range n.Args {
+			Inspect(arg, f)
+		}
+	case *PostfixExpr:
+		Inspect(n.Left, f)
+	}
+}
+
+// inspectIdent calls Inspect on id if it is not nil.
+// It avoids passing a nil *Identifier to Inspect as a non-nil Node.
+func inspectIdent(id *Identifier, f func(Node) bool) {
+	if id != nil {
+		Inspect(id, f)
+	}
+}
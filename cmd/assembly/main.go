@@ -0,0 +1,386 @@
+/*
+Command assembly is an interpreter for the Assembly programming language.
+*/
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/dkmccandless/assembly"
+	"github.com/dkmccandless/assembly/amend"
+	"github.com/dkmccandless/assembly/debugger"
+	"github.com/dkmccandless/assembly/golden"
+	"github.com/dkmccandless/assembly/lsp"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/vet"
+)
+
+const helpmsg = `Command assembly is an interpreter for the Assembly programming language.
+
+Usage:	assembly [flags] [resolution name]
+	assembly [flags] debug [resolution name]
+	assembly [flags] amend [resolution name] [amendment name]
+	assembly [flags] test [-update] [directory or resolution name ...]
+	assembly [flags] vet [resolution name ...]
+	assembly [flags] lsp
+
+The debug command evaluates a resolution interactively, pausing before each clause.
+Enter help at the prompt for a list of debugger commands.
+
+The amend command applies an amendment to a resolution and prints the amended resolution.
+
+The test command carries out each resolution that has golden files, such as dues.out and dues.err
+alongside dues.asm, and reports any differences from the published output and errors they hold.
+Directories are searched recursively, and the current directory is searched by default.
+With -update, it rewrites the golden files of the resolutions named or found instead.
+
+The vet command reports suspicious constructs that are nevertheless legal, such as an if statement
+whose condition compares two constants or a Resolved clause that contains no recognized statement.
+
+The lsp command runs a Language Server Protocol server over standard input and output.
+
+The -secretary, -clerk, and -treasurer flags direct the output published by the Secretary,
+entered into the record by the Clerk, and reported by the Treasurer to stdout, stderr, or the named file.
+
+The -dialect flag selects the conventions by which integers are written in words:
+american (the default) writes "one hundred one" and "one billion" for 10^9, british writes
+"one hundred and one", and british-long also names 10^9 "one milliard" and 10^12 "one billion".
+
+The -lenient flag accepts common nonstandard forms of integers, such as "twenty one", "a hundred",
+"a dozen", and a cardinal without its parenthesized numeral, and reports each to standard error
+with a suggested canonical rewrite instead of as an error.
+
+The -dialect and -lenient flags apply to every command. The vet command reports the nonstandard
+forms that -lenient accepts as warnings, and the lsp command publishes them as warning diagnostics.
+
+With -format=json, errors that halt a resolution are written to standard error
+as JSON objects, one per line, with fields severity, code, message, file, line, and column.
+Warnings are written in the same way with severity warning and an additional suggestion field.
+
+Flags:
+`
+
+// traceFlag is the value of the -trace flag: the name of the file to which the minutes are written,
+// or "" to write them to standard error. It may be given without a value.
+type traceFlag struct {
+	set  bool
+	name string
+}
+
+func (f *traceFlag) String() string { return f.name }
+
+func (f *traceFlag) Set(s string) error {
+	f.set = true
+	if s != "true" {
+		f.name = s
+	}
+	return nil
+}
+
+func (f *traceFlag) IsBoolFlag() bool { return true }
+
+// dialects holds the dialects that may be named by the -dialect flag.
+var dialects = map[string]object.Dialect{
+	"american":     object.American,
+	"british":      object.British,
+	"british-long": object.BritishLongScale,
+}
+
+// dialectFlag is the value of the -dialect flag.
+type dialectFlag struct {
+	name    string
+	dialect object.Dialect
+}
+
+func (f *dialectFlag) String() string { return f.name }
+
+func (f *dialectFlag) Set(s string) error {
+	d, ok := dialects[s]
+	if !ok {
+		return fmt.Errorf("unknown dialect %q", s)
+	}
+	f.name, f.dialect = s, d
+	return nil
+}
+
+func main() {
+	var trace traceFlag
+	flag.Var(&trace, "trace", "record the minutes of the proceedings to standard error, or to the named `file`")
+	format := flag.String("format", "text", "report errors as `text` or json")
+	dialect := dialectFlag{name: "american"}
+	flag.Var(&dialect, "dialect", "write integers in words in `american`, british, or british-long usage")
+	lenient := flag.Bool("lenient", false, "accept common nonstandard forms of integers with warnings")
+	var officers officerFlags
+	flag.StringVar(&officers.secretary, "secretary", "stdout", "send the Secretary's publications to stdout, stderr, or the named `file`")
+	flag.StringVar(&officers.clerk, "clerk", "stdout", "send the Clerk's entries into the record to stdout, stderr, or the named `file`")
+	flag.StringVar(&officers.treasurer, "treasurer", "stdout", "send the Treasurer's reports to stdout, stderr, or the named `file`")
+	flag.Usage = func() {
+		fmt.Fprint(flag.CommandLine.Output(), helpmsg)
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	args := flag.Args()
+
+	if len(args) == 0 || strings.ToLower(args[0]) == "help" {
+		flag.Usage()
+		return
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "unknown format %q\n", *format)
+		flag.Usage()
+		os.Exit(2)
+	}
+	switch args[0] {
+	case "lsp":
+		opts := lsp.Options{Incorporate: true, Dialect: dialect.dialect, Lenient: *lenient}
+		if err := lsp.Serve(os.Stdin, os.Stdout, opts); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	case "debug":
+		if len(args) != 2 {
+			flag.Usage()
+			return
+		}
+		debug(args[1], debugger.Options{Dialect: dialect.dialect, Lenient: *lenient})
+	case "amend":
+		if len(args) != 3 {
+			flag.Usage()
+			return
+		}
+		amendFile(args[1], args[2], amend.Options{Dialect: dialect.dialect, Lenient: *lenient})
+	case "vet":
+		if len(args) < 2 {
+			flag.Usage()
+			return
+		}
+		if !vetFiles(args[1:], vet.Options{Dialect: dialect.dialect, Lenient: *lenient}) {
+			os.Exit(1)
+		}
+	case "test":
+		if !test(args[1:], golden.Options{Dialect: dialect.dialect, Lenient: *lenient}) {
+			os.Exit(1)
+		}
+	default:
+		if !run(args[0], &trace, &officers, *format, dialect.dialect, *lenient) {
+			os.Exit(1)
+		}
+	}
+}
+
+// officerFlags holds the values of the -secretary, -clerk, and -treasurer flags.
+type officerFlags struct {
+	secretary, clerk, treasurer string
+}
+
+// outputs opens the destinations named by the values of the -secretary, -clerk, and -treasurer flags,
+// "stdout", "stderr", or a file name, and returns them with a function that closes any files opened.
+// Officers given the same file name share a single file.
+func (f *officerFlags) outputs() (secretary, clerk, treasurer io.Writer, closeAll func(), err error) {
+	files := make(map[string]*os.File)
+	closeAll = func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	open := func(name string) (io.Writer, error) {
+		switch name {
+		case "stdout":
+			return os.Stdout, nil
+		case "stderr":
+			return os.Stderr, nil
+		}
+		if f, ok := files[name]; ok {
+			return f, nil
+		}
+		f, err := os.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = f
+		return f, nil
+	}
+	if secretary, err = open(f.secretary); err != nil {
+		closeAll()
+		return nil, nil, nil, nil, err
+	}
+	if clerk, err = open(f.clerk); err != nil {
+		closeAll()
+		return nil, nil, nil, nil, err
+	}
+	if treasurer, err = open(f.treasurer); err != nil {
+		closeAll()
+		return nil, nil, nil, nil, err
+	}
+	return secretary, clerk, treasurer, closeAll, nil
+}
+
+// run evaluates the resolution in the named file, directing each officer's output as given by officers
+// and reporting errors, and in lenient mode warnings, in the given format.
+// It reports whether the resolution was carried out without error.
+func run(filename string, trace *traceFlag, officers *officerFlags, format string, dialect object.Dialect, lenient bool) bool {
+	report := func(err error) {
+		if format != "json" {
+			fmt.Println(err)
+			return
+		}
+		enc := json.NewEncoder(os.Stderr)
+		for _, d := range assembly.Diagnostics(filename, err) {
+			enc.Encode(d)
+		}
+	}
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		report(err)
+		return false
+	}
+	secretary, clerk, treasurer, closeOutputs, err := officers.outputs()
+	if err != nil {
+		report(err)
+		return false
+	}
+	defer closeOutputs()
+	opts := assembly.Options{Filename: filename, Incorporate: true, Stdout: secretary, Clerk: clerk, Treasurer: treasurer, Dialect: dialect, Lenient: lenient}
+	if trace.set {
+		opts.Trace = os.Stderr
+		if trace.name != "" {
+			f, err := os.Create(trace.name)
+			if err != nil {
+				report(err)
+				return false
+			}
+			defer f.Close()
+			opts.Trace = f
+		}
+	}
+	res, err := assembly.Run(context.Background(), string(b), opts)
+	if format == "json" {
+		enc := json.NewEncoder(os.Stderr)
+		for _, d := range assembly.WarningDiagnostics(filename, res.Warnings) {
+			enc.Encode(d)
+		}
+	} else {
+		for _, w := range res.Warnings {
+			fmt.Fprintf(os.Stderr, "%v:%v\n", filename, w)
+		}
+	}
+	if err != nil {
+		report(err)
+		return false
+	}
+	return true
+}
+
+// debug evaluates the resolution in the named file in the debugger.
+func debug(filename string, opts debugger.Options) {
+	b, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	d, err := debugger.NewFile(filename, string(b), os.Stdin, os.Stdout, opts)
+	if err != nil {
+		fmt.Printf("%v: %v\n", filename, err)
+		return
+	}
+	if err := d.Run(); err != nil {
+		fmt.Println(err)
+	}
+}
+
+// amendFile prints the resolution in the named file as amended by the amendment in the named file.
+func amendFile(filename, amendment string, opts amend.Options) {
+	base, err := ioutil.ReadFile(filename)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	a, err := ioutil.ReadFile(amendment)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	src, _, err := amend.ApplyFile(filename, string(base), string(a), opts)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(src)
+}
+
+// test checks the resolutions found in args against their golden files, or updates the golden files,
+// and reports whether every check passed.
+func test(args []string, opts golden.Options) bool {
+	fs := flag.NewFlagSet("test", flag.ExitOnError)
+	update := fs.Bool("update", false, "rewrite the golden files instead of checking them")
+	fs.Parse(args)
+	paths := fs.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	files, err := golden.Find(paths...)
+	if err != nil {
+		fmt.Println(err)
+		return false
+	}
+	ctx := context.Background()
+	failed := 0
+	for _, filename := range files {
+		if *update {
+			if _, err := golden.Update(ctx, filename, opts); err != nil {
+				fmt.Println(err)
+				return false
+			}
+			fmt.Printf("updated\t%v\n", filename)
+			continue
+		}
+		r, err := golden.Check(ctx, filename, opts)
+		if err != nil {
+			fmt.Println(err)
+			return false
+		}
+		if r.Passed() {
+			fmt.Printf("ok\t%v\n", filename)
+			continue
+		}
+		failed++
+		fmt.Printf("FAIL\t%v\n%s", filename, r.Diff)
+	}
+	if failed > 0 {
+		fmt.Printf("FAIL: %d of %d resolutions\n", failed, len(files))
+		return false
+	}
+	return true
+}
+
+// vetFiles reports the suspicious constructs in the resolutions in the named files
+// and reports whether there were none.
+func vetFiles(filenames []string, opts vet.Options) bool {
+	ok := true
+	for _, filename := range filenames {
+		b, err := ioutil.ReadFile(filename)
+		if err != nil {
+			fmt.Println(err)
+			ok = false
+			continue
+		}
+		ws, err := vet.Check(filename, string(b), opts)
+		if err != nil {
+			fmt.Printf("%v: %v\n", filename, err)
+			ok = false
+			continue
+		}
+		for _, w := range ws {
+			fmt.Printf("%v:%v\n", filename, w)
+			ok = false
+		}
+	}
+	return ok
+}
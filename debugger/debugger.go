@@ -0,0 +1,299 @@
+/*
+Package debugger implements an interactive step debugger for Assembly resolutions.
+
+The debugger pauses before each clause that contains a statement and reads commands
+that control execution, manage breakpoints, and examine the state of the resolution:
+
+	step, s           evaluate the next statement, pausing within if statements
+	next, n           evaluate statements until the next clause
+	continue, c       evaluate statements until a breakpoint
+	break N, b N      pause before clause N
+	watch Name, w Name
+	                  pause before any statement that assigns to Name
+	clear             remove all breakpoints
+	env, e            list the variables and their values
+	print X, p X      evaluate the expression X
+	list, l           show the text of the current clause
+	quit, q           stop evaluating the resolution
+	help, h           list the commands
+
+Clauses are numbered in order beginning with one (1), counting both Whereas and Resolved clauses.
+*/
+package debugger
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/eval"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+	"github.com/dkmccandless/assembly/token"
+)
+
+// ErrQuit indicates that the user ended the debugging session before the resolution finished.
+var ErrQuit = errors.New("debugging session ended")
+
+const helpmsg = `Commands:
+	step, s            evaluate the next statement, pausing within if statements
+	next, n            evaluate statements until the next clause
+	continue, c        evaluate statements until a breakpoint
+	break N, b N       pause before clause N
+	watch Name, w Name pause before any statement that assigns to Name
+	clear              remove all breakpoints
+	env, e             list the variables and their values
+	print X, p X       evaluate the expression X
+	list, l            show the text of the current clause
+	quit, q            stop evaluating the resolution
+	help, h            list the commands`
+
+// mode describes when the debugger next pauses.
+type mode int
+
+const (
+	stepMode     mode = iota // before the next statement
+	nextMode                 // before the next clause
+	continueMode             // at the next breakpoint
+)
+
+// A Debugger evaluates a resolution under the control of commands read from its input.
+type Debugger struct {
+	// filename is the name of the file from which src was read, if known.
+	filename string
+
+	opts Options
+
+	src     string
+	p       *parser.Parser
+	res     *ast.Resolution
+	clauses []parser.Clause
+
+	// clause maps each statement that constitutes a clause to its index in clauses.
+	clause map[ast.Node]int
+
+	in  *bufio.Scanner
+	out io.Writer
+
+	mode    mode
+	breaks  map[int]bool
+	watches map[string]bool
+
+	// cur is the index in clauses of the clause being evaluated.
+	cur int
+
+	// quit records that the user has ended the session.
+	quit bool
+}
+
+// Options configure the parsing and evaluation of a resolution.
+type Options struct {
+	// Dialect holds the conventions by which integers are written in words,
+	// both in the resolution and in the expressions given to print, and in what is published and printed.
+	// The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// Lenient, if set, accepts common nonstandard forms of integers.
+	Lenient bool
+}
+
+// New parses src and returns a Debugger that reads commands from in and writes to out.
+// Resolutions incorporated by reference are found relative to the current directory.
+// If src cannot be parsed, New returns the parsing error.
+func New(src string, in io.Reader, out io.Writer) (*Debugger, error) {
+	return NewFile("", src, in, out, Options{})
+}
+
+// NewFile is like New, but for src read from the file filename, parsed and evaluated as opts configure.
+// Resolutions incorporated by reference are found relative to the directory containing filename.
+func NewFile(filename, src string, in io.Reader, out io.Writer, opts Options) (*Debugger, error) {
+	p := parser.NewFile(filename, lexer.New(src))
+	p.SetReadFile(ioutil.ReadFile)
+	p.SetDialect(opts.Dialect)
+	p.SetLenient(opts.Lenient)
+	res, err := p.ParseResolution()
+	if err != nil {
+		return nil, err
+	}
+	d := &Debugger{
+		filename: filename,
+		opts:     opts,
+		src:      src,
+		p:        p,
+		res:      res,
+		clauses:  p.Clauses(),
+		clause:   make(map[ast.Node]int),
+		in:       bufio.NewScanner(in),
+		out:      out,
+		mode:     nextMode,
+		breaks:   make(map[int]bool),
+		watches:  make(map[string]bool),
+	}
+	for i, c := range d.clauses {
+		if c.Stmt != nil {
+			d.clause[c.Stmt] = i
+		}
+	}
+	return d, nil
+}
+
+// Run evaluates the resolution. It returns ErrQuit if the user ends the session,
+// or an error describing the runtime error that halted evaluation, if any.
+func (d *Debugger) Run() error {
+	e := &eval.Evaluator{Out: d.out, Before: d.before, Dialect: d.opts.Dialect}
+	obj := e.Eval(d.res, object.NewEnvironment())
+	switch {
+	case d.quit:
+		return ErrQuit
+	case obj != nil:
+		return errors.New(obj.Inspect())
+	default:
+		fmt.Fprintln(d.out, "The resolution has been carried out.")
+		return nil
+	}
+}
+
+// before is called before each statement is evaluated, and pauses if appropriate.
+func (d *Debugger) before(stmt ast.Node, env *object.Environment) object.Object {
+	i, isClause := d.clause[stmt]
+	if isClause {
+		d.cur = i
+	}
+	if !d.shouldPause(stmt, isClause) {
+		return nil
+	}
+	if isClause {
+		fmt.Fprintf(d.out, "clause %d (%v):\n\t%v\n", i+1, d.clauses[i], d.text(i))
+	} else {
+		fmt.Fprintf(d.out, "clause %d, %v statement at %v within an if statement\n", d.cur+1, stmt, d.p.Pos(stmt))
+	}
+	for {
+		fmt.Fprint(d.out, "(debug) ")
+		if !d.in.Scan() {
+			// Out of commands: carry out the rest of the resolution.
+			fmt.Fprintln(d.out)
+			d.mode = continueMode
+			d.breaks, d.watches = nil, nil
+			return nil
+		}
+		cmd, arg := split(d.in.Text())
+		switch cmd {
+		case "":
+		case "step", "s":
+			d.mode = stepMode
+			return nil
+		case "next", "n":
+			d.mode = nextMode
+			return nil
+		case "continue", "c":
+			d.mode = continueMode
+			return nil
+		case "break", "b":
+			n, err := strconv.Atoi(arg)
+			if err != nil || n < 1 || n > len(d.clauses) {
+				fmt.Fprintf(d.out, "break: no clause %q\n", arg)
+				continue
+			}
+			d.breaks[n-1] = true
+			fmt.Fprintf(d.out, "breakpoint set before clause %d\n", n)
+		case "watch", "w":
+			if !token.IsIdentifier(arg) {
+				fmt.Fprintf(d.out, "watch: %q is not an identifier\n", arg)
+				continue
+			}
+			d.watches[arg] = true
+			fmt.Fprintf(d.out, "watching assignments to %v\n", arg)
+		case "clear":
+			d.breaks = make(map[int]bool)
+			d.watches = make(map[string]bool)
+			fmt.Fprintln(d.out, "breakpoints cleared")
+		case "env", "e":
+			d.printEnv(env)
+		case "print", "p":
+			d.print(arg, env)
+		case "list", "l":
+			fmt.Fprintf(d.out, "clause %d (%v):\n\t%v\n", d.cur+1, d.clauses[d.cur], d.text(d.cur))
+		case "quit", "q":
+			d.quit = true
+			return &object.Error{Value: ErrQuit.Error()}
+		case "help", "h":
+			fmt.Fprintln(d.out, helpmsg)
+		default:
+			fmt.Fprintf(d.out, "unknown command %q; enter help for a list of commands\n", cmd)
+		}
+	}
+}
+
+// shouldPause reports whether the debugger should pause before stmt.
+func (d *Debugger) shouldPause(stmt ast.Node, isClause bool) bool {
+	switch {
+	case d.mode == stepMode,
+		d.mode == nextMode && isClause,
+		isClause && d.breaks[d.clause[stmt]]:
+		return true
+	}
+	if id := ast.Assignee(stmt); id != nil && d.watches[id.Value] {
+		return true
+	}
+	return false
+}
+
+// text returns the source text of the clause at index i.
+func (d *Debugger) text(i int) string {
+	return strings.Join(strings.Fields(d.clauses[i].Text(d.src)), " ")
+}
+
+func (d *Debugger) printEnv(env *object.Environment) {
+	names := env.Names()
+	if len(names) == 0 {
+		fmt.Fprintln(d.out, "no variables")
+	}
+	for _, name := range names {
+		obj, _ := env.Get(name)
+		fmt.Fprintf(d.out, "%v = %v\n", name, d.inspect(obj))
+	}
+}
+
+// print evaluates the expression in src in env and prints its value.
+func (d *Debugger) print(src string, env *object.Environment) {
+	p := parser.NewFile(d.filename, lexer.New(src))
+	p.SetDialect(d.opts.Dialect)
+	p.SetLenient(d.opts.Lenient)
+	for _, name := range env.Names() {
+		p.Declare(name)
+	}
+	expr, err := p.ParseExpr()
+	if err != nil {
+		fmt.Fprintf(d.out, "print: %v\n", err)
+		return
+	}
+	obj := (&eval.Evaluator{Dialect: d.opts.Dialect}).Eval(expr, env)
+	if obj == nil {
+		fmt.Fprintln(d.out, "print: no value")
+		return
+	}
+	fmt.Fprintln(d.out, d.inspect(obj))
+}
+
+// inspect returns the text of obj, writing integers in d's dialect.
+func (d *Debugger) inspect(obj object.Object) string {
+	if i, ok := obj.(*object.Integer); ok {
+		return i.InspectIn(d.opts.Dialect)
+	}
+	return obj.Inspect()
+}
+
+// split splits line into a command and its argument.
+func split(line string) (cmd, arg string) {
+	line = strings.TrimSpace(line)
+	if i := strings.IndexAny(line, " \t"); i >= 0 {
+		return strings.ToLower(line[:i]), strings.TrimSpace(line[i+1:])
+	}
+	return strings.ToLower(line), ""
+}
@@ -0,0 +1,142 @@
+package debugger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+const testSrc = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is two (2);
+WHEREAS the Required Quorum (hereinafter Quorum) is three (3): now, therefore,
+
+BE IT RESOLVED that Count assume the value sum Count one (1);
+BE IT FURTHER RESOLVED that this Assembly takes note of the Count;
+BE IT FURTHER RESOLVED that if Count exceeds Quorum less one (1), the Secretary shall publish "Quorum reached.";
+BE IT FURTHER RESOLVED that the Secretary shall publish the aforesaid Count.`
+
+func TestRun(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		commands string
+		want     []string // in order
+		err      error
+	}{
+		{
+			"next",
+			"n\nn\nn\nn\nn\n",
+			[]string{
+				"clause 1 (Whereas clause 1):\n\tWHEREAS the Count of Members (hereinafter Count) is two (2);\n(debug) ",
+				"clause 2 (Whereas clause 2):",
+				"clause 3 (Resolved clause 1):\n\tRESOLVED that Count assume the value sum Count one (1);\n",
+				"clause 5 (Resolved clause 3):",
+				"Quorum reached.\n",
+				"clause 6 (Resolved clause 4):",
+				"three (3)\nThe resolution has been carried out.\n",
+			},
+			nil,
+		},
+		{
+			"step into if statement",
+			"break 5\nc\ns\ne\nc\n",
+			[]string{
+				"breakpoint set before clause 5\n",
+				"clause 5 (Resolved clause 3):",
+				"clause 5, publish statement at 8:87 within an if statement\n",
+				"Count = three (3)\nQuorum = three (3)\n",
+				"Quorum reached.\nthree (3)\n",
+			},
+			nil,
+		},
+		{
+			"watch and print",
+			"watch Count\nc\np sum Count Quorum\np Unknown\nq\n",
+			[]string{
+				"watching assignments to Count\n",
+				"clause 3 (Resolved clause 1):",
+				"(debug) five (5)\n",
+				"print: 1:1: Unknown undeclared\n",
+			},
+			ErrQuit,
+		},
+		{
+			"out of commands",
+			"",
+			[]string{"clause 1 (Whereas clause 1):", "Quorum reached.\nthree (3)\n"},
+			nil,
+		},
+	} {
+		var out bytes.Buffer
+		d, err := New(testSrc, strings.NewReader(test.commands), &out)
+		if err != nil {
+			t.Fatalf("New: unexpected error: %v", err)
+		}
+		if err := d.Run(); err != test.err {
+			t.Errorf("%v: got error %v, want %v", test.name, err, test.err)
+		}
+		got := out.String()
+		for _, want := range test.want {
+			i := strings.Index(got, want)
+			if i < 0 {
+				t.Errorf("%v: output %q does not contain %q", test.name, out.String(), want)
+				break
+			}
+			got = got[i+len(want):]
+		}
+	}
+}
+
+func TestNewFile(t *testing.T) {
+	const src = `A Resolution Concerning the Quorum
+
+WHEREAS the resolution "definitions.asm" is incorporated by reference: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Quorum.`
+	var out bytes.Buffer
+	d, err := NewFile("testdata/quorum.asm", src, strings.NewReader("c\n"), &out, Options{})
+	if err != nil {
+		t.Fatalf("NewFile: unexpected error: %v", err)
+	}
+	if err := d.Run(); err != nil {
+		t.Errorf("Run: unexpected error: %v", err)
+	}
+	if want := "three (3)\n"; !strings.Contains(out.String(), want) {
+		t.Errorf("Run: output %q does not contain %q", out.String(), want)
+	}
+
+	// Without the file name, definitions.asm is sought in the current directory.
+	if _, err := New(src, strings.NewReader(""), &out); err == nil {
+		t.Error("New: got no error, want incorporation error")
+	}
+}
+
+func TestNewFileOptions(t *testing.T) {
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is one hundred and one (101): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Count.`
+	var out bytes.Buffer
+	if _, err := NewFile("", src, strings.NewReader(""), &out, Options{}); err == nil {
+		t.Error("NewFile: got no error in American usage")
+	}
+	d, err := NewFile("", src, strings.NewReader("n\np Count less one (1)\np a dozen\nc\n"), &out, Options{Dialect: object.British, Lenient: true})
+	if err != nil {
+		t.Fatalf("NewFile: unexpected error: %v", err)
+	}
+	if err := d.Run(); err != nil {
+		t.Errorf("Run: unexpected error: %v", err)
+	}
+	got := out.String()
+	for _, want := range []string{"one hundred (100)\n", "twelve (12)\n", "one hundred and one (101)\n"} {
+		i := strings.Index(got, want)
+		if i < 0 {
+			t.Errorf("output %q does not contain %q", out.String(), want)
+			break
+		}
+		got = got[i+len(want):]
+	}
+}
@@ -0,0 +1,3 @@
+Definitions of the Assembly
+
+WHEREAS the Required Quorum (hereinafter Quorum) is three (3).
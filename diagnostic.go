@@ -0,0 +1,99 @@
+package assembly
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/dkmccandless/assembly/parser"
+	"github.com/dkmccandless/assembly/token"
+)
+
+// A Diagnostic describes a single error or warning in a form suitable for processing by programs,
+// such as editors and continuous integration systems.
+type Diagnostic struct {
+	// Severity is "error" or "warning".
+	Severity string `json:"severity"`
+
+	// Code is a short, stable identifier for the kind of error, such as "undeclared" or "division-by-zero".
+	Code string `json:"code"`
+
+	// Message describes the error without its position.
+	Message string `json:"message"`
+
+	// File, Line, and Column locate the error in the source, if its location is known.
+	// Line and Column are 1-based.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+
+	// EndLine and EndColumn, if set, locate the character following the construct that a warning describes.
+	EndLine   int `json:"endLine,omitempty"`
+	EndColumn int `json:"endColumn,omitempty"`
+
+	// Suggestion, if set, is the canonical text that may replace the construct that a warning describes.
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// Diagnostics returns the Diagnostics describing err, an error returned by Run
+// while carrying out the resolution in the named file.
+// A list of parsing errors yields one Diagnostic for each. If err is nil, Diagnostics returns nil.
+func Diagnostics(filename string, err error) []Diagnostic {
+	if err == nil {
+		return nil
+	}
+	if el, ok := err.(parser.ErrorList); ok {
+		var ds []Diagnostic
+		for _, err := range el {
+			ds = append(ds, Diagnostics(filename, err)...)
+		}
+		return ds
+	}
+	var (
+		perr *parser.Error
+		rerr *RuntimeError
+		ferr *os.PathError
+	)
+	switch {
+	case errors.As(err, &perr):
+		return []Diagnostic{newDiagnostic(filename, perr.Pos, perr.Code(), perr.Err.Error())}
+	case errors.As(err, &rerr):
+		code := rerr.Err.Code
+		if code == "" {
+			code = "runtime"
+		}
+		return []Diagnostic{newDiagnostic(filename, rerr.Pos, code, rerr.Err.Value)}
+	case errors.Is(err, context.Canceled):
+		return []Diagnostic{newDiagnostic(filename, token.Pos{}, "canceled", err.Error())}
+	case errors.Is(err, context.DeadlineExceeded):
+		return []Diagnostic{newDiagnostic(filename, token.Pos{}, "deadline-exceeded", err.Error())}
+	case errors.As(err, &ferr):
+		return []Diagnostic{newDiagnostic(ferr.Path, token.Pos{}, "file", err.Error())}
+	default:
+		return []Diagnostic{newDiagnostic(filename, token.Pos{}, "invalid-options", err.Error())}
+	}
+}
+
+// WarningDiagnostics returns the Diagnostics describing ws, the Warnings in a Result
+// of carrying out the resolution in the named file.
+func WarningDiagnostics(filename string, ws []parser.Warning) []Diagnostic {
+	var ds []Diagnostic
+	for _, w := range ws {
+		d := newDiagnostic(filename, w.Pos, "nonstandard", w.Message)
+		d.Severity, d.Suggestion = "warning", w.Suggestion
+		if w.End.IsValid() {
+			d.EndLine, d.EndColumn = w.End.Line, w.End.Col
+		}
+		ds = append(ds, d)
+	}
+	return ds
+}
+
+// newDiagnostic returns an error Diagnostic located at pos in the named file, if pos is valid.
+func newDiagnostic(filename string, pos token.Pos, code, msg string) Diagnostic {
+	d := Diagnostic{Severity: "error", Code: code, Message: msg, File: filename}
+	if pos.IsValid() {
+		d.Line, d.Column = pos.Line, pos.Col
+	}
+	return d
+}
@@ -0,0 +1,72 @@
+package eval
+
+import "math"
+
+// mul returns a*b and reports whether the product is representable as an int64.
+func mul(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || a == -1 && b == math.MinInt64 || b == -1 && a == math.MinInt64 {
+		return 0, false
+	}
+	return c, true
+}
+
+// pow returns a raised to the power of n, which must not be negative,
+// and reports whether the result is representable as an int64.
+func pow(a, n int64) (int64, bool) {
+	switch {
+	case n == 0:
+		return 1, true
+	case a == 0 || a == 1:
+		return a, true
+	case a == -1 && n%2 == 0:
+		return 1, true
+	case a == -1:
+		return -1, true
+	}
+	// The magnitude of a is at least two (2), so the loop overflows within sixty-four (64) iterations.
+	result := int64(1)
+	for ; n > 0; n-- {
+		var ok bool
+		if result, ok = mul(result, a); !ok {
+			return 0, false
+		}
+	}
+	return result, true
+}
+
+// abs returns the absolute value of a and reports whether it is representable as an int64.
+func abs(a int64) (int64, bool) {
+	switch {
+	case a == math.MinInt64:
+		return 0, false
+	case a < 0:
+		return -a, true
+	default:
+		return a, true
+	}
+}
+
+// factorial returns n!, where n must not be negative, and reports whether it is representable as an int64.
+func factorial(n int64) (int64, bool) {
+	result := int64(1)
+	for i := int64(2); i <= n; i++ {
+		var ok bool
+		if result, ok = mul(result, i); !ok {
+			return 0, false
+		}
+	}
+	return result, true
+}
+
+// gcd returns the greatest common divisor of a and b, which is not negative,
+// and reports whether it is representable as an int64.
+func gcd(a, b int64) (int64, bool) {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return abs(a)
+}
@@ -0,0 +1,88 @@
+package eval
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMul(t *testing.T) {
+	for _, test := range []struct {
+		a, b, n int64
+		ok      bool
+	}{
+		{0, math.MinInt64, 0, true},
+		{-3, 4, -12, true},
+		{math.MaxInt64, -1, -math.MaxInt64, true},
+		{math.MinInt64, 1, math.MinInt64, true},
+		{math.MinInt64, -1, 0, false},
+		{-1, math.MinInt64, 0, false},
+		{1 << 32, 1 << 31, 0, false},
+		{1 << 31, -1 << 32, math.MinInt64, true},
+	} {
+		if n, ok := mul(test.a, test.b); n != test.n || ok != test.ok {
+			t.Errorf("mul(%v, %v): got %v, %v; want %v, %v", test.a, test.b, n, ok, test.n, test.ok)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	for _, test := range []struct {
+		a, n, want int64
+		ok         bool
+	}{
+		{0, 0, 1, true},
+		{0, 5, 0, true},
+		{1, math.MaxInt64, 1, true},
+		{-1, math.MaxInt64, -1, true},
+		{-1, math.MaxInt64 - 1, 1, true},
+		{3, 4, 81, true},
+		{-2, 63, math.MinInt64, true},
+		{2, 63, 0, false},
+		{10, 18, 1e18, true},
+		{10, 19, 0, false},
+		{2, math.MaxInt64, 0, false},
+	} {
+		if n, ok := pow(test.a, test.n); n != test.want || ok != test.ok {
+			t.Errorf("pow(%v, %v): got %v, %v; want %v, %v", test.a, test.n, n, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestFactorial(t *testing.T) {
+	for _, test := range []struct {
+		n, want int64
+		ok      bool
+	}{
+		{0, 1, true},
+		{1, 1, true},
+		{10, 3628800, true},
+		{20, 2432902008176640000, true},
+		{21, 0, false},
+		{math.MaxInt64, 0, false},
+	} {
+		if n, ok := factorial(test.n); n != test.want || ok != test.ok {
+			t.Errorf("factorial(%v): got %v, %v; want %v, %v", test.n, n, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestGCD(t *testing.T) {
+	for _, test := range []struct {
+		a, b, want int64
+		ok         bool
+	}{
+		{12, 18, 6, true},
+		{-12, 18, 6, true},
+		{12, -18, 6, true},
+		{0, 0, 0, true},
+		{0, -7, 7, true},
+		{17, 5, 1, true},
+		{math.MinInt64, 6, 2, true},
+		{math.MinInt64, 0, 0, false},
+		{math.MinInt64, math.MinInt64, 0, false},
+	} {
+		if n, ok := gcd(test.a, test.b); n != test.want || ok != test.ok {
+			t.Errorf("gcd(%v, %v): got %v, %v; want %v, %v", test.a, test.b, n, ok, test.want, test.ok)
+		}
+	}
+}
@@ -2,104 +2,267 @@ package eval
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/dkmccandless/assembly/ast"
 	"github.com/dkmccandless/assembly/object"
 	"github.com/dkmccandless/assembly/token"
 )
 
+// Eval evaluates node in env using the zero Evaluator.
 func Eval(node ast.Node, env *object.Environment) object.Object {
+	return new(Evaluator).Eval(node, env)
+}
+
+// An Evaluator evaluates Assembly ASTs.
+// The zero value is an Evaluator that publishes to standard output and calls no hooks.
+type Evaluator struct {
+	// Out receives the output published by the Secretary. If Out is nil, os.Stdout is used.
+	Out io.Writer
+
+	// Clerk and Treasurer receive the output entered into the record by the Clerk
+	// and reported by the Treasurer. If either is nil, its output is sent to Out.
+	Clerk, Treasurer io.Writer
+
+	// Before, if non-nil, is called before each statement is evaluated,
+	// including the consequence of an if statement.
+	// If it returns a non-nil Object, the statement is not evaluated
+	// and the Object is returned as the statement's result.
+	Before func(stmt ast.Node, env *object.Environment) object.Object
+
+	// After, if non-nil, is called after each statement is evaluated
+	// with the statement's result, which is nil if evaluation succeeded.
+	After func(stmt ast.Node, env *object.Environment, result object.Object)
+
+	// Condition, if non-nil, is called after the condition of each if statement is evaluated
+	// and reports whether it held.
+	Condition func(stmt *ast.IfStmt, held bool)
+
+	// Builtins holds the functions that resolutions may call, keyed by name.
+	Builtins map[string]*object.Builtin
+
+	// Dialect holds the conventions by which integers are published, interpolated, and described in errors in words.
+	// The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// halt holds the Object most recently returned by Before, which halts evaluation
+	// and cannot be recovered.
+	halt object.Object
+}
+
+// out returns the Writer that receives the output of the named officer.
+func (e *Evaluator) out(officer string) io.Writer {
+	switch {
+	case officer == ast.Clerk && e.Clerk != nil:
+		return e.Clerk
+	case officer == ast.Treasurer && e.Treasurer != nil:
+		return e.Treasurer
+	case e.Out == nil:
+		return os.Stdout
+	default:
+		return e.Out
+	}
+}
+
+// Eval evaluates node in env.
+func (e *Evaluator) Eval(node ast.Node, env *object.Environment) object.Object {
+	switch node.(type) {
+	case *ast.DeclStmt, *ast.AssumeStmt, *ast.IfStmt, *ast.AffirmStmt, *ast.PublishStmt, *ast.CommitteeStmt, *ast.IncorporationStmt:
+		if e.Before != nil {
+			if obj := e.Before(node, env); obj != nil {
+				e.halt = obj
+				return obj
+			}
+		}
+		result := e.eval(node, env)
+		if e.After != nil {
+			e.After(node, env, result)
+		}
+		return result
+	default:
+		return e.eval(node, env)
+	}
+}
+
+func (e *Evaluator) eval(node ast.Node, env *object.Environment) object.Object {
 	switch node := node.(type) {
 	case *ast.IntegerLiteral:
 		return &object.Integer{Value: node.Value}
 	case *ast.StringLiteral:
 		return &object.String{Value: node.Value}
+	case *ast.InterpolatedString:
+		var b strings.Builder
+		for _, part := range node.Parts {
+			val := e.Eval(part, env)
+			if isError(val) {
+				return val
+			}
+			if val != nil {
+				b.WriteString(e.inspect(val))
+			}
+		}
+		return &object.String{Value: b.String()}
 	case *ast.UnaryPrefixExpr:
-		right := Eval(node.Right, env)
+		right := e.Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalUnaryPrefixExpr(node.Token, right)
+		return e.evalUnaryPrefixExpr(node.Token, right)
 	case *ast.BinaryPrefixExpr:
-		first := Eval(node.First, env)
+		first := e.Eval(node.First, env)
 		if isError(first) {
 			return first
 		}
-		second := Eval(node.Second, env)
+		second := e.Eval(node.Second, env)
 		if isError(second) {
 			return second
 		}
-		return evalBinaryPrefixExpr(node.Token, first, second)
+		return e.evalBinaryPrefixExpr(node.Token, first, second)
 	case *ast.InfixExpr:
-		left := Eval(node.Left, env)
+		left := e.Eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		right := Eval(node.Right, env)
+		right := e.Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		return evalInfixExpr(node.Token, left, right)
+		return e.evalInfixExpr(node.Token, left, right)
 	case *ast.PostfixExpr:
-		left := Eval(node.Left, env)
+		left := e.Eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		return evalPostfixExpr(node.Token, left)
+		return e.evalPostfixExpr(node.Token, left)
+	case *ast.CallExpr:
+		name := node.Function.Value
+		fn, ok := e.Builtins[name]
+		if !ok {
+			return &object.Error{Value: fmt.Sprintf("unknown function %v", name), Code: "unknown-function"}
+		}
+		args := make([]object.Object, 0, len(node.Args))
+		for _, arg := range node.Args {
+			val := e.Eval(arg, env)
+			if isError(val) {
+				return val
+			}
+			args = append(args, val)
+		}
+		result := fn.Fn(args...)
+		if result == nil {
+			return &object.Error{Value: fmt.Sprintf("%v returned no finding", name), Code: "no-finding"}
+		}
+		return result
 	case *ast.Identifier:
 		// An ast.Identifier is created for every capitalized non-keyword;
 		// return nil if the "identifier" is not in env.
 		if obj, ok := env.Get(node.Value); ok {
 			return obj
 		}
+	case *ast.IncorporationStmt:
+		for _, stmt := range node.Stmts {
+			if err := e.Eval(stmt, env); err != nil {
+				return err
+			}
+		}
 	case *ast.DeclStmt:
-		if val := Eval(node.Value, env); val != nil {
+		val := e.Eval(node.Value, env)
+		// A statement whose value is an error fails without assigning or publishing it,
+		// halting the resolution unless a recovery clause follows.
+		if isError(val) {
+			return val
+		}
+		if val != nil {
 			env.Set(node.Name.Value, val)
 		}
 	case *ast.AssumeStmt:
-		if val := Eval(node.Value, env); val != nil {
-			env.Set(node.Name.Value, val)
+		val := e.Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if val != nil {
+			env.Assign(node.Name.Value, val)
 		}
 	case *ast.IfStmt:
-		left := Eval(node.Left, env)
+		left := e.Eval(node.Left, env)
 		if isError(left) {
 			return left
 		}
-		right := Eval(node.Right, env)
+		right := e.Eval(node.Right, env)
 		if isError(right) {
 			return right
 		}
-		if left.Type() != right.Type() {
-			return typeMismatchError(left, right)
+		condition, err := e.evalRelation(node.Relation, left, right)
+		if err != nil {
+			return err
 		}
-		var condition bool
-		switch node.Relation.Typ {
-		case token.EQUALS:
-			condition = left == right
-		case token.EXCEEDS:
-			if left.Type() != object.INTEGER {
-				return nonNumericError(left)
-			}
-			condition = left.(*object.Integer).Value > right.(*object.Integer).Value
+		if e.Condition != nil {
+			e.Condition(node, condition)
 		}
 		if condition {
-			err := Eval(node.Consequence, env)
+			err := e.Eval(node.Consequence, env)
 			if err != nil {
 				return err
 			}
 		}
+	case *ast.AffirmStmt:
+		left := e.Eval(node.Left, env)
+		if isError(left) {
+			return left
+		}
+		right := e.Eval(node.Right, env)
+		if isError(right) {
+			return right
+		}
+		held, err := e.evalRelation(node.Relation, left, right)
+		if err != nil {
+			return err
+		}
+		if !held {
+			return e.affirmationError(node.Relation, left, right)
+		}
 	case *ast.PublishStmt:
-		if val := Eval(node.Value, env); val != nil {
-			fmt.Println(val.Inspect())
+		val := e.Eval(node.Value, env)
+		if isError(val) {
+			return val
+		}
+		if val != nil {
+			text, err := e.format(val, node.Format)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(e.out(node.Responsible()), text)
+		}
+	case *ast.RecoverStmt:
+		obj := e.Eval(node.Stmt, env)
+		if !isError(obj) || obj == e.halt {
+			return obj
+		}
+		if node.Fallback == nil {
+			return nil
+		}
+		if node.Name != nil {
+			env = object.NewEnclosedEnvironment(env)
+			env.Set(node.Name.Value, &object.String{Value: obj.Inspect()})
+		}
+		return e.Eval(node.Fallback, env)
+	case *ast.CommitteeStmt:
+		inner := object.NewEnclosedEnvironment(env)
+		for _, stmt := range node.Body {
+			if err := e.Eval(stmt, inner); err != nil {
+				return err
+			}
 		}
 	case *ast.Resolution:
 		for _, wh := range node.WhereasStmts {
-			if err := Eval(wh, env); err != nil {
+			if err := e.Eval(wh, env); err != nil {
 				return err
 			}
 		}
 		for _, res := range node.ResolvedStmts {
-			if err := Eval(res, env); err != nil {
+			if err := e.Eval(res, env); err != nil {
 				return err
 			}
 		}
@@ -107,9 +270,56 @@ func Eval(node ast.Node, env *object.Environment) object.Object {
 	return nil
 }
 
-func evalUnaryPrefixExpr(t token.Token, right object.Object) object.Object {
+// evalRelation reports whether relation holds between left and right.
+func (e *Evaluator) evalRelation(relation token.Token, left, right object.Object) (bool, *object.Error) {
+	if left.Type() != right.Type() {
+		return false, typeMismatchError(left, right)
+	}
+	switch relation.Typ {
+	case token.EQUALS:
+		return equal(left, right), nil
+	case token.NOTEQUAL:
+		return !equal(left, right), nil
+	case token.EXCEEDS, token.ATLEAST, token.ATMOST, token.LESSTHAN:
+		if left.Type() != object.INTEGER {
+			return false, e.nonNumericError(left)
+		}
+		l, r := left.(*object.Integer).Value, right.(*object.Integer).Value
+		switch relation.Typ {
+		case token.EXCEEDS:
+			return l > r, nil
+		case token.ATLEAST:
+			return l >= r, nil
+		case token.ATMOST:
+			return l <= r, nil
+		default:
+			return l < r, nil
+		}
+	case token.PRECEDES:
+		if left.Type() != object.STRING {
+			return false, e.nonStringError(left)
+		}
+		return left.(*object.String).Value < right.(*object.String).Value, nil
+	default:
+		return false, unknownOperatorError(fmt.Sprintf("%v %v %v", e.inspect(left), relation.Lit, e.inspect(right)))
+	}
+}
+
+// equal reports whether a and b, which are of the same type, have the same value.
+func equal(a, b object.Object) bool {
+	switch a := a.(type) {
+	case *object.Integer:
+		return a.Value == b.(*object.Integer).Value
+	case *object.String:
+		return a.Value == b.(*object.String).Value
+	default:
+		return a == b
+	}
+}
+
+func (e *Evaluator) evalUnaryPrefixExpr(t token.Token, right object.Object) object.Object {
 	if right.Type() != object.INTEGER {
-		return nonNumericError(right)
+		return e.nonNumericError(right)
 	}
 	r := right.(*object.Integer).Value
 	switch t.Typ {
@@ -117,18 +327,33 @@ func evalUnaryPrefixExpr(t token.Token, right object.Object) object.Object {
 		return &object.Integer{2 * r}
 	case token.THRICE:
 		return &object.Integer{3 * r}
+	case token.ABSOLUTE:
+		n, ok := abs(r)
+		if !ok {
+			return overflowError(t)
+		}
+		return &object.Integer{n}
+	case token.FACTORIAL:
+		if r < 0 {
+			return e.undefinedError(t, right)
+		}
+		n, ok := factorial(r)
+		if !ok {
+			return overflowError(t)
+		}
+		return &object.Integer{n}
 	default:
-		return &object.Error{fmt.Sprintf("unknown operator %v %v", t.Lit, r)}
+		return unknownOperatorError(fmt.Sprintf("%v %v", t.Lit, r))
 	}
 }
 
-func evalBinaryPrefixExpr(t token.Token, first, second object.Object) object.Object {
+func (e *Evaluator) evalBinaryPrefixExpr(t token.Token, first, second object.Object) object.Object {
 	if first.Type() != object.INTEGER {
-		return nonNumericError(first)
+		return e.nonNumericError(first)
 	}
 	a := first.(*object.Integer).Value
 	if second.Type() != object.INTEGER {
-		return nonNumericError(second)
+		return e.nonNumericError(second)
 	}
 	b := second.(*object.Integer).Value
 	switch t.Typ {
@@ -137,34 +362,65 @@ func evalBinaryPrefixExpr(t token.Token, first, second object.Object) object.Obj
 	case token.PRODUCT:
 		return &object.Integer{a * b}
 	case token.QUOTIENT:
+		if b == 0 {
+			return divisionByZeroError(t)
+		}
 		return &object.Integer{a / b}
 	case token.REMAINDER:
+		if b == 0 {
+			return divisionByZeroError(t)
+		}
 		return &object.Integer{a % b}
+	case token.GREATER:
+		if a > b {
+			return first
+		}
+		return second
+	case token.LESSER:
+		if a < b {
+			return first
+		}
+		return second
+	case token.GREATEST:
+		n, ok := gcd(a, b)
+		if !ok {
+			return overflowError(t)
+		}
+		return &object.Integer{n}
 	default:
-		return &object.Error{fmt.Sprintf("unknown operator %v %v %v", t.Lit, a, b)}
+		return unknownOperatorError(fmt.Sprintf("%v %v %v", t.Lit, a, b))
 	}
 }
 
-func evalInfixExpr(t token.Token, left, right object.Object) object.Object {
+func (e *Evaluator) evalInfixExpr(t token.Token, left, right object.Object) object.Object {
 	if left.Type() != object.INTEGER {
-		return nonNumericError(left)
+		return e.nonNumericError(left)
 	}
 	l := left.(*object.Integer).Value
 	if right.Type() != object.INTEGER {
-		return nonNumericError(right)
+		return e.nonNumericError(right)
 	}
 	r := right.(*object.Integer).Value
 	switch t.Typ {
 	case token.LESS:
 		return &object.Integer{l - r}
+	case token.RAISED:
+		if r < 0 {
+			return e.undefinedError(t, right)
+		}
+		n, ok := pow(l, r)
+		if !ok {
+			return overflowError(t)
+		}
+		return &object.Integer{n}
 	default:
-		return &object.Error{fmt.Sprintf("unknown operator %v %v %v", l, t.Lit, r)}
+		return unknownOperatorError(fmt.Sprintf("%v %v %v", l, t.Lit, r))
 	}
 }
 
-func evalPostfixExpr(t token.Token, left object.Object) object.Object {
+func (e *Evaluator) evalPostfixExpr(t token.Token, left object.Object) object.Object {
 	if left.Type() != object.INTEGER {
-		return nonNumericError(left)
+		return e.nonNumericError(left)
 	}
 	l := left.(*object.Integer).Value
 	switch t.Typ {
@@ -173,18 +429,97 @@ func evalPostfixExpr(t token.Token, left object.Object) object.Object {
 	case token.CUBED:
 		return &object.Integer{l * l * l}
 	default:
-		return &object.Error{fmt.Sprintf("unknown operator %v %v", l, t.Lit)}
+		return unknownOperatorError(fmt.Sprintf("%v %v", l, t.Lit))
 	}
 }
 
+// unknownOperatorError records that the operation described by op is not defined.
+func unknownOperatorError(op string) *object.Error {
+	return &object.Error{Value: "unknown operator " + op, Code: "unknown-operator"}
+}
+
 // typeMismatchError records that a and b are different types.
 func typeMismatchError(a, b object.Object) *object.Error {
-	return &object.Error{fmt.Sprintf("mismatched types %v and %v", a.Type(), b.Type())}
+	return &object.Error{Value: fmt.Sprintf("mismatched types %v and %v", a.Type(), b.Type()), Code: "type-mismatch"}
+}
+
+// divisionByZeroError records that the divisor of the operator t is zero.
+func divisionByZeroError(t token.Token) *object.Error {
+	return &object.Error{Value: fmt.Sprintf("division by zero in %v", t.Lit), Code: "division-by-zero"}
+}
+
+// affirmationError records that relation does not hold between left and right.
+func (e *Evaluator) affirmationError(relation token.Token, left, right object.Object) *object.Error {
+	return &object.Error{
+		Value: fmt.Sprintf("affirmation failed: %s %s %s", e.inspect(left), negations[relation.Typ], e.inspect(right)),
+		Code:  "affirmation-failed",
+	}
+}
+
+// inspect returns the text of obj, writing integers in e.Dialect.
+func (e *Evaluator) inspect(obj object.Object) string {
+	if i, ok := obj.(*object.Integer); ok {
+		return i.InspectIn(e.Dialect)
+	}
+	return obj.Inspect()
+}
+
+// format returns the text of obj in the format denoted by f, or e.inspect(obj) if f is the zero Token.
+func (e *Evaluator) format(obj object.Object, f token.Token) (string, *object.Error) {
+	if f == (token.Token{}) {
+		return e.inspect(obj), nil
+	}
+	i, ok := obj.(*object.Integer)
+	if !ok {
+		return "", e.nonNumericError(obj)
+	}
+	switch f.Typ {
+	case token.WORDS:
+		return e.Dialect.Words(i.Value), nil
+	case token.FIGURES:
+		return object.Figures(i.Value), nil
+	case token.ORDINAL:
+		return e.Dialect.Ordinal(i.Value), nil
+	case token.ROMAN:
+		if r, ok := object.Roman(i.Value); ok {
+			return r, nil
+		}
+		return "", &object.Error{Value: fmt.Sprintf("no Roman numeral for %v", e.inspect(i)), Code: "no-roman-numeral"}
+	default:
+		return "", unknownOperatorError(fmt.Sprintf("%v in %v", e.inspect(i), f.Lit))
+	}
+}
+
+// overflowError records that the result of the operator t is not representable in sixty-four (64) bits.
+func overflowError(t token.Token) *object.Error {
+	return &object.Error{Value: fmt.Sprintf("integer overflow in %v", t.Lit), Code: "overflow"}
+}
+
+// undefinedError records that the operator t is undefined for the operand obj,
+// such as the factorial of a negative number.
+func (e *Evaluator) undefinedError(t token.Token, obj object.Object) *object.Error {
+	return &object.Error{Value: fmt.Sprintf("%v undefined for %v", t.Lit, e.inspect(obj)), Code: "undefined"}
+}
+
+// negations holds the relation that holds between two operands when each relational operator does not.
+var negations = map[token.Type]string{
+	token.EQUALS:   "does not equal",
+	token.NOTEQUAL: "equals",
+	token.EXCEEDS:  "does not exceed",
+	token.ATLEAST:  "is less than",
+	token.ATMOST:   "exceeds",
+	token.LESSTHAN: "is not less than",
+	token.PRECEDES: "does not precede",
 }
 
 // nonNumericError records that obj occurs in an expression context that requires a numeric form.
-func nonNumericError(obj object.Object) *object.Error {
-	return &object.Error{fmt.Sprintf("non-numeric %s in numeric context", obj.Inspect())}
+func (e *Evaluator) nonNumericError(obj object.Object) *object.Error {
+	return &object.Error{Value: fmt.Sprintf("non-numeric %s in numeric context", e.inspect(obj)), Code: "non-numeric"}
+}
+
+// nonStringError records that obj occurs in an expression context that requires a string.
+func (e *Evaluator) nonStringError(obj object.Object) *object.Error {
+	return &object.Error{Value: fmt.Sprintf("non-string %s in alphabetical comparison", e.inspect(obj)), Code: "non-string"}
 }
 
 func isError(obj object.Object) bool { return obj != nil && obj.Type() == object.ERROR }
@@ -1,6 +1,8 @@
 package eval
 
 import (
+	"bytes"
+	"io/ioutil"
 	"math"
 	"reflect"
 	"testing"
@@ -254,7 +256,7 @@ func TestIfStmt(t *testing.T) {
 				},
 			},
 			"Error",
-			&object.Integer{1},
+			&object.Integer{2},
 		},
 		{
 			&ast.IfStmt{
@@ -325,6 +327,41 @@ func TestEvalUnaryPrefixExpr(t *testing.T) {
 			},
 			&object.Integer{-6},
 		},
+		{
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.ABSOLUTE, "absolute"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "-3"}, -3},
+			},
+			&object.Integer{3},
+		},
+		{
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.ABSOLUTE, "absolute"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "-9223372036854775808"}, math.MinInt64},
+			},
+			&object.Error{Value: "integer overflow in absolute", Code: "overflow"},
+		},
+		{
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.FACTORIAL, "factorial"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "5"}, 5},
+			},
+			&object.Integer{120},
+		},
+		{
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.FACTORIAL, "factorial"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "21"}, 21},
+			},
+			&object.Error{Value: "integer overflow in factorial", Code: "overflow"},
+		},
+		{
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.FACTORIAL, "factorial"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "-1"}, -1},
+			},
+			&object.Error{Value: "factorial undefined for negative one (-1)", Code: "undefined"},
+		},
 	} {
 		if obj := Eval(test.ast, object.NewEnvironment()); !reflect.DeepEqual(obj, test.obj) {
 			t.Errorf("EvalUnaryPrefixExpr(%+v): got %+v, want %+v", test.ast, obj, test.obj)
@@ -369,6 +406,46 @@ func TestEvalBinaryPrefixExpr(t *testing.T) {
 			},
 			&object.Integer{2},
 		},
+		{
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.QUOTIENT, "quotient"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "17"}, 17},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "0"}, 0},
+			},
+			&object.Error{Value: "division by zero in quotient", Code: "division-by-zero"},
+		},
+		{
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.REMAINDER, "remainder"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "17"}, 17},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "0"}, 0},
+			},
+			&object.Error{Value: "division by zero in remainder", Code: "division-by-zero"},
+		},
+		{
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.GREATER, "greater"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "12"}, 12},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "-5"}, -5},
+			},
+			&object.Integer{12},
+		},
+		{
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.LESSER, "lesser"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "12"}, 12},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "-5"}, -5},
+			},
+			&object.Integer{-5},
+		},
+		{
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.GREATEST, "greatest"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "-12"}, -12},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "18"}, 18},
+			},
+			&object.Integer{6},
+		},
 	} {
 		if obj := Eval(test.ast, object.NewEnvironment()); !reflect.DeepEqual(obj, test.obj) {
 			t.Errorf("EvalBinaryPrefixExpr(%+v): got %+v, want %+v", test.ast, obj, test.obj)
@@ -389,6 +466,38 @@ func TestEvalInfixExpr(t *testing.T) {
 			},
 			&object.Integer{1},
 		},
+		{
+			&ast.InfixExpr{
+				Token: token.Token{token.RAISED, "raised"},
+				Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "10"}, 10},
+			},
+			&object.Integer{1024},
+		},
+		{
+			&ast.InfixExpr{
+				Token: token.Token{token.RAISED, "raised"},
+				Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "-2"}, -2},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "63"}, 63},
+			},
+			&object.Integer{math.MinInt64},
+		},
+		{
+			&ast.InfixExpr{
+				Token: token.Token{token.RAISED, "raised"},
+				Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "63"}, 63},
+			},
+			&object.Error{Value: "integer overflow in raised", Code: "overflow"},
+		},
+		{
+			&ast.InfixExpr{
+				Token: token.Token{token.RAISED, "raised"},
+				Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "-1"}, -1},
+			},
+			&object.Error{Value: "raised undefined for negative one (-1)", Code: "undefined"},
+		},
 	} {
 		if obj := Eval(test.ast, object.NewEnvironment()); !reflect.DeepEqual(obj, test.obj) {
 			t.Errorf("EvalInfixExpr(%+v): got %+v, want %+v", test.ast, obj, test.obj)
@@ -431,3 +540,340 @@ func TestEvalPostfixExpr(t *testing.T) {
 		}
 	}
 }
+
+func TestEvaluatorHooks(t *testing.T) {
+	publish := &ast.PublishStmt{
+		Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+		Value: &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: "Adjourned."}, Value: "Adjourned."},
+	}
+	cond := &ast.IfStmt{
+		Token:       token.Token{Typ: token.IF, Lit: "if"},
+		Left:        &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: "2"}, Value: 2},
+		Right:       &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: "1"}, Value: 1},
+		Relation:    token.Token{Typ: token.EXCEEDS, Lit: "exceeds"},
+		Consequence: publish,
+	}
+	res := &ast.Resolution{ResolvedStmts: []ast.ResolvedStmt{cond, publish}}
+
+	var out bytes.Buffer
+	var events []string
+	e := &Evaluator{
+		Out: &out,
+		Before: func(stmt ast.Node, env *object.Environment) object.Object {
+			events = append(events, "before "+stmt.String())
+			return nil
+		},
+		After: func(stmt ast.Node, env *object.Environment, result object.Object) {
+			events = append(events, "after "+stmt.String())
+		},
+	}
+	if obj := e.Eval(res, object.NewEnvironment()); obj != nil {
+		t.Fatalf("Eval: got %v, want nil", obj)
+	}
+	if want := []string{"before if", "before publish", "after publish", "after if", "before publish", "after publish"}; !reflect.DeepEqual(events, want) {
+		t.Errorf("Eval: got events %v, want %v", events, want)
+	}
+	if got, want := out.String(), "Adjourned.\nAdjourned.\n"; got != want {
+		t.Errorf("Eval: got output %q, want %q", got, want)
+	}
+
+	// A non-nil result from Before halts evaluation.
+	out.Reset()
+	halt := &object.Error{Value: "halted"}
+	e.Before = func(stmt ast.Node, env *object.Environment) object.Object { return halt }
+	if obj := e.Eval(res, object.NewEnvironment()); obj != halt {
+		t.Errorf("Eval: got %v, want %v", obj, halt)
+	}
+	if out.Len() != 0 {
+		t.Errorf("Eval: got output %q after halting", out.String())
+	}
+}
+
+func TestEvalCallExpr(t *testing.T) {
+	audit := &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: "Audit"}, Value: "Audit"}
+	ledger := &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: "Ledger"}, Value: "Ledger"}
+	call := func(name *ast.Identifier, args ...ast.Expr) *ast.CallExpr {
+		return &ast.CallExpr{Token: token.Token{Typ: token.FINDING, Lit: "finding"}, Function: name, Args: args}
+	}
+	e := &Evaluator{Builtins: map[string]*object.Builtin{
+		"Audit": {Name: "Audit", Fn: func(args ...object.Object) object.Object {
+			var sum int64
+			for _, arg := range args {
+				n, ok := arg.(*object.Integer)
+				if !ok {
+					return &object.Error{Value: "the Audit requires integers"}
+				}
+				sum += n.Value
+			}
+			return &object.Integer{Value: sum}
+		}},
+		"Review": {Name: "Review", Fn: func(args ...object.Object) object.Object { return nil }},
+	}}
+	for _, test := range []struct {
+		expr ast.Expr
+		want object.Object
+	}{
+		{call(audit), &object.Integer{Value: 0}},
+		{call(audit, ledger, &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: "2"}, Value: 2}), &object.Integer{Value: 7}},
+		{call(audit, &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: "x"}, Value: "x"}), &object.Error{Value: "the Audit requires integers"}},
+		{call(&ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: "Review"}, Value: "Review"}), &object.Error{Value: "Review returned no finding", Code: "no-finding"}},
+		{call(&ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: "Census"}, Value: "Census"}), &object.Error{Value: "unknown function Census", Code: "unknown-function"}},
+	} {
+		env := object.NewEnvironment()
+		env.Set("Ledger", &object.Integer{Value: 5})
+		if got := e.Eval(test.expr, env); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("Eval(%v): got %#v, want %#v", test.expr, got, test.want)
+		}
+	}
+}
+
+func TestCommitteeStmt(t *testing.T) {
+	ident := func(name string) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+	}
+	one := &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: "1"}, Value: 1}
+	stmt := &ast.CommitteeStmt{
+		Token: token.Token{Typ: token.COMMITTEE, Lit: "Committee"},
+		Body: []ast.ResolvedStmt{
+			// Members shadows the enclosing variable of the same name.
+			&ast.DeclStmt{Token: token.Token{Typ: token.HEREINAFTER, Lit: "hereinafter"}, Name: ident("Members"), Value: one},
+			&ast.DeclStmt{Token: token.Token{Typ: token.HEREINAFTER, Lit: "hereinafter"}, Name: ident("Tally"), Value: ident("Total")},
+			&ast.AssumeStmt{
+				Token: token.Token{Typ: token.ASSUME, Lit: "assume"},
+				Name:  ident("Total"),
+				Value: &ast.BinaryPrefixExpr{Token: token.Token{Typ: token.SUM, Lit: "sum"}, First: ident("Tally"), Second: ident("Members")},
+			},
+		},
+	}
+	env := object.NewEnvironment()
+	env.Set("Members", &object.Integer{Value: 5})
+	env.Set("Total", &object.Integer{Value: 10})
+	if obj := Eval(stmt, env); obj != nil {
+		t.Fatalf("Eval: got %v, want nil", obj.Inspect())
+	}
+	if got, want := env.Names(), []string{"Members", "Total"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Eval: got variables %v, want %v", got, want)
+	}
+	for name, want := range map[string]int64{"Members": 5, "Total": 11} {
+		if got, _ := env.Get(name); !reflect.DeepEqual(got, &object.Integer{Value: want}) {
+			t.Errorf("Eval: got %v %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestEvalInterpolatedString(t *testing.T) {
+	str := func(s string) *ast.StringLiteral {
+		return &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: s}, Value: s}
+	}
+	ident := func(name string) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+	}
+	env := object.NewEnvironment()
+	env.Set("Total", &object.Integer{Value: 12})
+	env.Set("Chair", &object.String{Value: "Zoë"})
+	for _, test := range []struct {
+		parts []ast.Expr
+		obj   object.Object
+	}{
+		{[]ast.Expr{ident("Total"), str(" members are present.")}, &object.String{Value: "twelve (12) members are present."}},
+		{[]ast.Expr{str("The Chair, "), ident("Chair"), str(", presides.")}, &object.String{Value: "The Chair, Zoë, presides."}},
+		{
+			[]ast.Expr{&ast.UnaryPrefixExpr{Token: token.Token{Typ: token.TWICE, Lit: "twice"}, Right: ident("Chair")}},
+			&object.Error{Value: "non-numeric Zoë in numeric context", Code: "non-numeric"},
+		},
+	} {
+		node := &ast.InterpolatedString{Token: token.Token{Typ: token.STRING}, Parts: test.parts}
+		if obj := Eval(node, env); !reflect.DeepEqual(obj, test.obj) {
+			t.Errorf("Eval(%v): got %v, want %v", test.parts, obj, test.obj)
+		}
+	}
+}
+
+func TestAffirmStmt(t *testing.T) {
+	ident := func(name string) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+	}
+	affirm := func(left ast.Expr, relation token.Token, right ast.Expr) *ast.AffirmStmt {
+		return &ast.AffirmStmt{Token: token.Token{Typ: token.AFFIRMS, Lit: "affirms"}, Left: left, Relation: relation, Right: right}
+	}
+	equals := token.Token{Typ: token.EQUALS, Lit: "equals"}
+	exceeds := token.Token{Typ: token.EXCEEDS, Lit: "exceeds"}
+	notEqual := token.Token{Typ: token.NOTEQUAL, Lit: "does not equal"}
+	atLeast := token.Token{Typ: token.ATLEAST, Lit: "is at least"}
+	atMost := token.Token{Typ: token.ATMOST, Lit: "is at most"}
+	lessThan := token.Token{Typ: token.LESSTHAN, Lit: "is less than"}
+	precedes := token.Token{Typ: token.PRECEDES, Lit: "precedes"}
+	hello := &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: "Hello"}, Value: "Hello"}
+	hundred := &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: "100"}, Value: 100}
+	for _, test := range []struct {
+		stmt *ast.AffirmStmt
+		obj  object.Object
+	}{
+		{affirm(ident("Total"), equals, hundred), nil},
+		{affirm(ident("Greeting"), equals, &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: "Hello"}, Value: "Hello"}), nil},
+		{affirm(ident("Total"), exceeds, ident("Count")), nil},
+		{
+			affirm(ident("Count"), equals, hundred),
+			&object.Error{Value: "affirmation failed: ninety-nine (99) does not equal one hundred (100)", Code: "affirmation-failed"},
+		},
+		{
+			affirm(ident("Count"), exceeds, ident("Total")),
+			&object.Error{Value: "affirmation failed: ninety-nine (99) does not exceed one hundred (100)", Code: "affirmation-failed"},
+		},
+		{
+			affirm(ident("Greeting"), equals, hundred),
+			&object.Error{Value: "mismatched types 1 and 0", Code: "type-mismatch"},
+		},
+		{affirm(ident("Count"), notEqual, hundred), nil},
+		{
+			affirm(ident("Total"), notEqual, hundred),
+			&object.Error{Value: "affirmation failed: one hundred (100) equals one hundred (100)", Code: "affirmation-failed"},
+		},
+		{affirm(ident("Total"), atLeast, hundred), nil},
+		{
+			affirm(ident("Count"), atLeast, hundred),
+			&object.Error{Value: "affirmation failed: ninety-nine (99) is less than one hundred (100)", Code: "affirmation-failed"},
+		},
+		{affirm(ident("Total"), atMost, hundred), nil},
+		{
+			affirm(ident("Total"), atMost, ident("Count")),
+			&object.Error{Value: "affirmation failed: one hundred (100) exceeds ninety-nine (99)", Code: "affirmation-failed"},
+		},
+		{affirm(ident("Count"), lessThan, hundred), nil},
+		{
+			affirm(ident("Total"), lessThan, hundred),
+			&object.Error{Value: "affirmation failed: one hundred (100) is not less than one hundred (100)", Code: "affirmation-failed"},
+		},
+		{affirm(ident("Greeting"), lessThan, hello), &object.Error{Value: "non-numeric Hello in numeric context", Code: "non-numeric"}},
+		{affirm(hello, precedes, &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: "World"}, Value: "World"}), nil},
+		{
+			affirm(ident("Greeting"), precedes, hello),
+			&object.Error{Value: "affirmation failed: Hello does not precede Hello", Code: "affirmation-failed"},
+		},
+		{affirm(ident("Count"), precedes, hundred), &object.Error{Value: "non-string ninety-nine (99) in alphabetical comparison", Code: "non-string"}},
+	} {
+		env := object.NewEnvironment()
+		env.Set("Total", &object.Integer{Value: 100})
+		env.Set("Count", &object.Integer{Value: 99})
+		env.Set("Greeting", &object.String{Value: "Hello"})
+		if obj := Eval(test.stmt, env); !reflect.DeepEqual(obj, test.obj) {
+			t.Errorf("Eval(%v %v %v): got %v, want %v", test.stmt.Left, test.stmt.Relation.Lit, test.stmt.Right, obj, test.obj)
+		}
+	}
+}
+
+func TestPublishFormat(t *testing.T) {
+	publish := func(value object.Object, format token.Type, lit string) *ast.PublishStmt {
+		var e ast.Expr
+		switch v := value.(type) {
+		case *object.Integer:
+			e = &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: v.Inspect()}, Value: v.Value}
+		case *object.String:
+			e = &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: v.Value}, Value: v.Value}
+		}
+		return &ast.PublishStmt{Token: token.Token{Typ: token.PUBLISH, Lit: "publish"}, Value: e, Format: token.Token{Typ: format, Lit: lit}}
+	}
+	for _, test := range []struct {
+		stmt *ast.PublishStmt
+		obj  object.Object
+		out  string
+	}{
+		{&ast.PublishStmt{Token: token.Token{Typ: token.PUBLISH, Lit: "publish"}, Value: &ast.IntegerLiteral{Value: 1021}}, nil, "one thousand twenty-one (1,021)\n"},
+		{publish(&object.Integer{Value: 1021}, token.WORDS, "words"), nil, "one thousand twenty-one\n"},
+		{publish(&object.Integer{Value: 1021}, token.FIGURES, "figures"), nil, "1,021\n"},
+		{publish(&object.Integer{Value: 1021}, token.ORDINAL, "ordinal"), nil, "one thousand twenty-first (1,021st)\n"},
+		{publish(&object.Integer{Value: 1021}, token.ROMAN, "Roman"), nil, "MXXI\n"},
+		{
+			publish(&object.Integer{Value: 0}, token.ROMAN, "Roman"),
+			&object.Error{Value: "no Roman numeral for zero (0)", Code: "no-roman-numeral"},
+			"",
+		},
+		{
+			publish(&object.String{Value: "Hello"}, token.FIGURES, "figures"),
+			&object.Error{Value: "non-numeric Hello in numeric context", Code: "non-numeric"},
+			"",
+		},
+	} {
+		var out bytes.Buffer
+		obj := (&Evaluator{Out: &out}).Eval(test.stmt, object.NewEnvironment())
+		if !reflect.DeepEqual(obj, test.obj) || out.String() != test.out {
+			t.Errorf("Eval(publish %v in %v): got %v, %q; want %v, %q", test.stmt.Value, test.stmt.Format.Lit, obj, out.String(), test.obj, test.out)
+		}
+	}
+}
+
+// A statement whose value is an error fails, so that a recovery clause can recover it,
+// and publishes or assigns nothing.
+func TestStmtError(t *testing.T) {
+	greeting := &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: "Greeting"}, Value: "Greeting"}
+	twice := &ast.UnaryPrefixExpr{Token: token.Token{Typ: token.TWICE, Lit: "twice"}, Right: greeting}
+	for _, stmt := range []ast.Node{
+		&ast.DeclStmt{Token: token.Token{Typ: token.HEREINAFTER, Lit: "hereinafter"}, Name: greeting, Value: twice},
+		&ast.AssumeStmt{Token: token.Token{Typ: token.ASSUME, Lit: "assume"}, Name: greeting, Value: twice},
+		&ast.PublishStmt{Token: token.Token{Typ: token.PUBLISH, Lit: "publish"}, Value: twice},
+	} {
+		env := object.NewEnvironment()
+		env.Set("Greeting", &object.String{Value: "Hello"})
+		var out bytes.Buffer
+		obj := (&Evaluator{Out: &out}).Eval(stmt, env)
+		if !isError(obj) {
+			t.Errorf("Eval(%v): got %v, want error", stmt, obj)
+		}
+		if got, _ := env.Get("Greeting"); !reflect.DeepEqual(got, &object.String{Value: "Hello"}) {
+			t.Errorf("Eval(%v): Greeting assumed %v", stmt, got)
+		}
+		if out.Len() != 0 {
+			t.Errorf("Eval(%v): published %q", stmt, out.String())
+		}
+	}
+}
+
+func TestRecoverStmt(t *testing.T) {
+	ident := func(name string) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+	}
+	fail := token.Token{Typ: token.FAIL, Lit: "fail"}
+	publish := func(e ast.Expr) *ast.PublishStmt {
+		return &ast.PublishStmt{Token: token.Token{Typ: token.PUBLISH, Lit: "publish"}, Value: e}
+	}
+	twice := publish(&ast.UnaryPrefixExpr{Token: token.Token{Typ: token.TWICE, Lit: "twice"}, Right: ident("Greeting")})
+	for _, test := range []struct {
+		stmt *ast.RecoverStmt
+		obj  object.Object
+		out  string
+	}{
+		{&ast.RecoverStmt{Token: fail, Stmt: publish(ident("Greeting")), Fallback: publish(ident("Count"))}, nil, "Hello\n"},
+		{&ast.RecoverStmt{Token: fail, Stmt: twice, Fallback: publish(ident("Count"))}, nil, "one (1)\n"},
+		{&ast.RecoverStmt{Token: fail, Stmt: twice}, nil, ""},
+		{&ast.RecoverStmt{Token: fail, Stmt: twice, Name: ident("Problem"), Fallback: publish(ident("Problem"))}, nil, "non-numeric Hello in numeric context\n"},
+		{
+			&ast.RecoverStmt{Token: fail, Stmt: twice, Fallback: publish(&ast.UnaryPrefixExpr{Token: token.Token{Typ: token.TWICE, Lit: "twice"}, Right: ident("Greeting")})},
+			&object.Error{Value: "non-numeric Hello in numeric context", Code: "non-numeric"},
+			"",
+		},
+	} {
+		env := object.NewEnvironment()
+		env.Set("Greeting", &object.String{Value: "Hello"})
+		env.Set("Count", &object.Integer{Value: 1})
+		var out bytes.Buffer
+		obj := (&Evaluator{Out: &out}).Eval(test.stmt, env)
+		if !reflect.DeepEqual(obj, test.obj) || out.String() != test.out {
+			t.Errorf("Eval(%v): got %v, %q; want %v, %q", test.stmt, obj, out.String(), test.obj, test.out)
+		}
+		if _, ok := env.Get("Problem"); ok {
+			t.Errorf("Eval(%v): Problem bound after recovery", test.stmt)
+		}
+	}
+
+	// An Object returned by Before halts evaluation and is not recovered.
+	halt := &object.Error{Value: "halted"}
+	e := &Evaluator{
+		Out: ioutil.Discard,
+		Before: func(stmt ast.Node, env *object.Environment) object.Object {
+			return halt
+		},
+	}
+	if obj := e.Eval(&ast.RecoverStmt{Token: fail, Stmt: publish(ident("Count")), Fallback: publish(ident("Count"))}, object.NewEnvironment()); obj != halt {
+		t.Errorf("Eval: got %v, want %v", obj, halt)
+	}
+}
@@ -0,0 +1,71 @@
+package golden
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Diff returns a line-by-line description of the changes that transform want, the contents of the named golden file, into got.
+// Lines only in want are prefixed with "-", lines only in got with "+", and common lines with " ".
+// Diff returns "" if want and got are equal.
+func Diff(name, want, got string) string {
+	if want == got {
+		return ""
+	}
+	a, b := lines(want), lines(got)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ got\n", name)
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			fmt.Fprintf(&sb, " %s\n", a[i])
+			i, j = i+1, j+1
+		case j == len(b) || i < len(a) && lcs[i+1][j] >= lcs[i][j+1]:
+			fmt.Fprintf(&sb, "-%s\n", a[i])
+			i++
+		default:
+			fmt.Fprintf(&sb, "+%s\n", b[j])
+			j++
+		}
+	}
+	return sb.String()
+}
+
+// lines splits s into lines. A final line without a terminating newline is marked as such.
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	ls := strings.SplitAfter(s, "\n")
+	if ls[len(ls)-1] == "" {
+		ls = ls[:len(ls)-1]
+	}
+	for i, l := range ls {
+		if strings.HasSuffix(l, "\n") {
+			ls[i] = strings.TrimSuffix(l, "\n")
+		} else {
+			ls[i] = l + " (no newline at end of file)"
+		}
+	}
+	return ls
+}
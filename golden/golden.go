@@ -0,0 +1,193 @@
+/*
+Package golden tests Assembly resolutions against the output they are expected to produce.
+
+A resolution in a file such as dues.asm is checked against golden files alongside it:
+dues.out holds the text it is expected to publish, and dues.err the errors it is expected to report,
+one per line. A missing golden file is equivalent to an empty one.
+A resolution is a test case if it has at least one golden file.
+*/
+package golden
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dkmccandless/assembly"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+)
+
+// Ext is the extension of resolution files.
+const Ext = ".asm"
+
+// OutPath and ErrPath return the names of the golden files holding the expected output
+// and expected errors of the resolution in the named file.
+func OutPath(filename string) string { return strings.TrimSuffix(filename, Ext) + ".out" }
+func ErrPath(filename string) string { return strings.TrimSuffix(filename, Ext) + ".err" }
+
+// Find returns the names of the resolution files in paths, in sorted order.
+// Directories are searched recursively for resolutions that have golden files.
+// Files are included whether or not they have golden files.
+func Find(paths ...string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		fi, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !fi.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.Walk(path, func(name string, fi os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if fi.IsDir() || filepath.Ext(name) != Ext {
+				return nil
+			}
+			if exists(OutPath(name)) || exists(ErrPath(name)) {
+				files = append(files, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// exists reports whether the named file exists.
+func exists(filename string) bool {
+	_, err := os.Stat(filename)
+	return err == nil
+}
+
+// A Result is the outcome of checking a resolution against its golden files.
+type Result struct {
+	// Filename names the resolution file.
+	Filename string
+
+	// Stdout and Stderr hold the text that the resolution published and the errors it reported.
+	Stdout, Stderr string
+
+	// Diff describes the differences between the expected and actual output and errors.
+	// It is empty if the resolution passed.
+	Diff string
+}
+
+// Passed reports whether the resolution produced its expected output and errors.
+func (r *Result) Passed() bool { return r.Diff == "" }
+
+// Options configure the carrying out of resolutions.
+// Each field is passed to assembly.Run as the field of assembly.Options of the same name.
+type Options struct {
+	Dialect object.Dialect
+	Lenient bool
+}
+
+// Check carries out the resolution in the named file and compares its output and errors to its golden files.
+func Check(ctx context.Context, filename string, opts Options) (*Result, error) {
+	r, err := run(ctx, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	wantOut, err := readGolden(OutPath(filename))
+	if err != nil {
+		return nil, err
+	}
+	wantErr, err := readGolden(ErrPath(filename))
+	if err != nil {
+		return nil, err
+	}
+	r.Diff = Diff(OutPath(filename), wantOut, r.Stdout) + Diff(ErrPath(filename), wantErr, r.Stderr)
+	return r, nil
+}
+
+// Update carries out the resolution in the named file and writes its output and errors to its golden files.
+// The error file is removed if the resolution reports no errors,
+// and the output file is written only if the resolution publishes output or reports no errors.
+func Update(ctx context.Context, filename string, opts Options) (*Result, error) {
+	r, err := run(ctx, filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	if r.Stdout != "" || r.Stderr == "" {
+		if err := ioutil.WriteFile(OutPath(filename), []byte(r.Stdout), 0666); err != nil {
+			return nil, err
+		}
+	} else if err := remove(OutPath(filename)); err != nil {
+		return nil, err
+	}
+	if r.Stderr != "" {
+		if err := ioutil.WriteFile(ErrPath(filename), []byte(r.Stderr), 0666); err != nil {
+			return nil, err
+		}
+	} else if err := remove(ErrPath(filename)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// remove removes the named file if it exists.
+func remove(filename string) error {
+	if err := os.Remove(filename); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// readGolden returns the contents of the named golden file, or "" if it does not exist.
+func readGolden(filename string) (string, error) {
+	b, err := ioutil.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return string(b), err
+}
+
+// run carries out the resolution in the named file and captures its output and errors.
+func run(ctx context.Context, filename string, opts Options) (*Result, error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var stdout bytes.Buffer
+	_, err = assembly.Run(ctx, string(src), assembly.Options{
+		Filename:    filename,
+		Incorporate: true,
+		Stdout:      &stdout,
+		Dialect:     opts.Dialect,
+		Lenient:     opts.Lenient,
+	})
+	return &Result{Filename: filename, Stdout: stdout.String(), Stderr: errorText(err)}, nil
+}
+
+// errorText formats err with one error per line.
+// Positions are given without the filename so that golden files do not depend on the directory from which they are checked.
+func errorText(err error) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	if el, ok := err.(parser.ErrorList); ok {
+		for _, err := range el {
+			fmt.Fprintln(&b, err)
+		}
+		return b.String()
+	}
+	if rerr, ok := err.(*assembly.RuntimeError); ok && rerr.Pos.IsValid() {
+		fmt.Fprintf(&b, "%v: %v\n", rerr.Pos, rerr)
+		return b.String()
+	}
+	fmt.Fprintln(&b, err)
+	return b.String()
+}
@@ -0,0 +1,149 @@
+package golden
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+func TestFind(t *testing.T) {
+	got, err := Find("testdata", "testdata/untested.asm")
+	if err != nil {
+		t.Fatalf("Find: unexpected error: %v", err)
+	}
+	// Each resolution with a golden file is found once, as is the resolution named explicitly.
+	found := map[string]bool{"testdata/untested.asm": true}
+	for _, pattern := range []string{"testdata/*.out", "testdata/*.err"} {
+		goldens, err := filepath.Glob(pattern)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range goldens {
+			found[strings.TrimSuffix(name, filepath.Ext(name))+Ext] = true
+		}
+	}
+	var want []string
+	for name := range found {
+		want = append(want, name)
+	}
+	sort.Strings(want)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Find: got %v, want %v", got, want)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	files, err := Find("testdata")
+	if err != nil {
+		t.Fatalf("Find: unexpected error: %v", err)
+	}
+	if len(files) == 0 {
+		t.Fatal("Find: found no resolutions in testdata")
+	}
+	for _, filename := range files {
+		r, err := Check(context.Background(), filename, Options{})
+		if err != nil {
+			t.Fatalf("Check(%v): unexpected error: %v", filename, err)
+		}
+		if !r.Passed() {
+			t.Errorf("Check(%v): failed:\n%s", filename, r.Diff)
+		}
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	src, err := ioutil.ReadFile("testdata/dues.asm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	filename := filepath.Join(dir, "dues.asm")
+	if err := ioutil.WriteFile(filename, src, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(ErrPath(filename), []byte("stale\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Check(context.Background(), filename, Options{})
+	if err != nil {
+		t.Fatalf("Check: unexpected error: %v", err)
+	}
+	if r.Passed() {
+		t.Fatal("Check: passed before Update")
+	}
+	if _, err := Update(context.Background(), filename, Options{}); err != nil {
+		t.Fatalf("Update: unexpected error: %v", err)
+	}
+	if exists(ErrPath(filename)) {
+		t.Errorf("Update: did not remove %v", ErrPath(filename))
+	}
+	r, err = Check(context.Background(), filename, Options{})
+	if err != nil {
+		t.Fatalf("Check: unexpected error: %v", err)
+	}
+	if !r.Passed() {
+		t.Errorf("Check: failed after Update:\n%s", r.Diff)
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is one hundred and one (101): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Count.`
+	filename := filepath.Join(dir, "british.asm")
+	if err := ioutil.WriteFile(filename, []byte(src), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(OutPath(filename), []byte("one hundred and one (101)\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		opts   Options
+		passed bool
+	}{
+		{Options{}, false},
+		{Options{Dialect: object.British}, true},
+	} {
+		r, err := Check(context.Background(), filename, test.opts)
+		if err != nil {
+			t.Fatalf("Check(%+v): unexpected error: %v", test.opts, err)
+		}
+		if r.Passed() != test.passed {
+			t.Errorf("Check(%+v): got passed %v, want %v:\n%s", test.opts, r.Passed(), test.passed, r.Diff)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	for _, test := range []struct {
+		want, got, diff string
+	}{
+		{"a\nb\n", "a\nb\n", ""},
+		{"a\nb\nc\n", "a\nc\nd\n", "--- x.out\n+++ got\n a\n-b\n c\n+d\n"},
+		{"", "a\n", "--- x.out\n+++ got\n+a\n"},
+		{"a\n", "a", "--- x.out\n+++ got\n-a\n+a (no newline at end of file)\n"},
+	} {
+		if diff := Diff("x.out", test.want, test.got); diff != test.diff {
+			t.Errorf("Diff(%q, %q): got %q, want %q", test.want, test.got, diff, test.diff)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+A Resolution Concerning the Accounts
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100); and
+WHEREAS the Count of Members (hereinafter Count) is four (4): now, therefore,
+
+BE IT RESOLVED that the Assembly affirms that Total equals one hundred (100); and
+BE IT FURTHER RESOLVED that the Assembly affirms that quotient Total Count exceeds thirty (30); and
+BE IT FURTHER RESOLVED that the Secretary shall publish "Unreachable".
@@ -0,0 +1,9 @@
+A Resolution Concerning the Capital Improvement Fund
+
+WHEREAS the sums set aside for repairs (hereinafter the Capital Improvement Fund) are two thousand (2,000); and
+WHEREAS the balance reported by the Treasurer (hereinafter the Treasurer's Report) is five hundred (500); and
+WHEREAS the Fund (hereinafter the Fund) is one (1): now, therefore,
+
+BE IT RESOLVED that the Capital Improvement Fund assume the value sum Capital Improvement Fund Treasurer’s Report; and
+BE IT FURTHER RESOLVED that the Secretary shall publish "The Fund stands at [Capital Improvement Fund] and [Fund]"; and
+BE IT FURTHER RESOLVED that the Treasurer shall report the Treasurer's Report.
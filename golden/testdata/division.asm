@@ -0,0 +1,7 @@
+A Resolution Concerning Division
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100); and
+WHEREAS the Count of Members (hereinafter Count) is zero (0): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish "Dividing"; and
+BE IT FURTHER RESOLVED that the Secretary shall publish quotient Total Count.
@@ -0,0 +1,8 @@
+A Resolution Concerning Attendance
+
+WHEREAS the Members of the Assembly (hereinafter Members) number twelve (12); and
+WHEREAS the Members Present (hereinafter Present) number nine (9); and
+WHEREAS the Presiding Officer (hereinafter Chair) is "Zoë Ibáñez": now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish "Of [Members] members, [Present] are present; [Chair] presides."; and
+BE IT FURTHER RESOLVED that the Secretary shall publish "The roll is recorded as [[Present] in the minutes.".
@@ -0,0 +1,12 @@
+A Resolution Concerning the Ruling of the Chair
+
+WHEREAS the Ruling of the Chair (hereinafter Ruling) is "The Chair ruled ""Out of order."""; and
+WHEREAS the Text of the Motion (hereinafter Motion) is "
+	WHEREAS the Chair has ruled;
+	  and the ruling stands:
+	now, therefore,
+	": now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Ruling; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Motion; and
+BE IT FURTHER RESOLVED that the Secretary shall publish "Item\tAmount\nDues\tone hundred (100)".
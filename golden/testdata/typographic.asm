@@ -0,0 +1,9 @@
+A Resolution Concerning the Delegation from Ñuñoa
+
+WHEREAS the Delegate of Ñuñoa (hereinafter Zoë) is “Zoë Ibáñez”; and
+WHEREAS the Members of the Delegation (hereinafter Delegación) number twenty‑five (25); and
+WHEREAS the Balance Owed (hereinafter Balance) is negative forty–two (−42): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the aforesaid Zoë; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Delegación; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Balance.
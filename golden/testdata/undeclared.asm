@@ -0,0 +1,6 @@
+A Resolution Concerning Nothing
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Count; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Members.
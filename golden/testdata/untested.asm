@@ -0,0 +1,5 @@
+A Resolution Without Golden Files
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Total.
@@ -2,110 +2,358 @@ package lexer
 
 import (
 	"errors"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/dkmccandless/assembly/token"
 )
 
-// errQuote indicates that a string literal is not terminated with a closing quotation mark before EOF.
-var errQuote = errors.New("no closing quotation mark")
+// ErrQuote indicates that a string literal is not terminated with a closing quotation mark before EOF.
+var ErrQuote = errors.New("no closing quotation mark")
 
-// Lexer tokenizes an input string.
+// ErrEscape indicates that a string literal contains an unknown escape sequence.
+var ErrEscape = errors.New(`unknown escape sequence; use \n, \t, \\, or \"`)
+
+// Lexer tokenizes UTF-8 encoded text, read either from a string or incrementally from an io.Reader.
+// Typographic quotation marks and dashes, such as those inserted by word processors,
+// are treated as their ASCII equivalents.
 type Lexer struct {
-	input        string
+	// r supplies input not yet in buf. It is nil once it has been exhausted or has returned an error.
+	r   io.Reader
+	err error
+
+	// buf holds the input from offset base on. Input before the start of the token being scanned
+	// is discarded as buf is refilled, so tokens are sliced from buf without per-character copying.
+	buf  []byte
+	base int
+
+	// pos and readPos are offsets within the whole input.
 	pos, readPos int
-	ch           byte
+	ch           rune
+
+	// line and col hold the position of ch.
+	line, col int
+
+	// start holds the position of the first byte of the most recently returned token.
+	start token.Pos
 }
 
+// bufSize is the size of the reads that NewReader's Lexer makes from its io.Reader.
+const bufSize = 64 << 10
+
 // New returns a Lexer for input.
 func New(input string) *Lexer {
-	l := &Lexer{input: input}
+	l := &Lexer{buf: []byte(input), line: 1}
 	l.readChar()
 	return l
 }
 
-// readChar advances l by one byte and stores the byte at readPos in ch.
-// Invariant: While readPos < len(l.input), readPos == pos + 1.
+// NewReader returns a Lexer that reads its input from r as needed.
+// The Lexer retains only the input from the start of the token being scanned.
+// If r returns an error other than io.EOF, Next returns it with a token.EOF
+// at the position where reading stopped.
+func NewReader(r io.Reader) *Lexer {
+	l := &Lexer{r: r, buf: make([]byte, 0, bufSize), line: 1}
+	l.readChar()
+	return l
+}
+
+// Pos returns the position of the first character of the token most recently returned by Next.
+func (l *Lexer) Pos() token.Pos { return l.start }
+
+// End returns the position following the last character of the token most recently returned by Next.
+func (l *Lexer) End() token.Pos { return token.Pos{Offset: l.pos, Line: l.line, Col: l.col} }
+
+// fill reads from l.r until buf holds the input up to offset end or the input is exhausted.
+// Input preceding the start of the current token is discarded to make room.
+func (l *Lexer) fill(end int) {
+	for l.r != nil && l.base+len(l.buf) < end {
+		if len(l.buf) == cap(l.buf) {
+			keep := l.start.Offset
+			if keep > l.pos {
+				keep = l.pos
+			}
+			n := copy(l.buf, l.buf[keep-l.base:])
+			l.buf, l.base = l.buf[:n], keep
+			if n > cap(l.buf)/2 {
+				// The current token occupies most of buf.
+				buf := make([]byte, n, 2*cap(l.buf))
+				copy(buf, l.buf)
+				l.buf = buf
+			}
+		}
+		n, err := l.r.Read(l.buf[len(l.buf):cap(l.buf)])
+		l.buf = l.buf[:len(l.buf)+n]
+		if err != nil {
+			if err != io.EOF {
+				l.err = err
+			}
+			l.r = nil
+		}
+	}
+}
+
+// text returns the input from offset start up to pos.
+func (l *Lexer) text(start int) string { return string(l.buf[start-l.base : l.pos-l.base]) }
+
+// readChar advances l by one character and stores the character beginning at readPos in ch.
+// Invariant: While ch != 0, readPos is the offset of the character following ch.
 func (l *Lexer) readChar() {
-	if l.readPos >= len(l.input) {
-		l.ch = 0
-	} else {
-		l.ch = l.input[l.readPos]
+	if l.ch == '\n' {
+		l.line++
+		l.col = 0
 	}
+	l.col++
 	l.pos = l.readPos
-	l.readPos++
+	l.fill(l.readPos + utf8.UTFMax)
+	if l.readPos-l.base >= len(l.buf) {
+		l.ch = 0
+		return
+	}
+	r, width := utf8.DecodeRune(l.buf[l.readPos-l.base:])
+	l.ch = r
+	l.readPos += width
+}
+
+// peekChar returns the character following ch, or 0 at the end of the input.
+func (l *Lexer) peekChar() rune {
+	l.fill(l.readPos + utf8.UTFMax)
+	if l.readPos-l.base >= len(l.buf) {
+		return 0
+	}
+	r, _ := utf8.DecodeRune(l.buf[l.readPos-l.base:])
+	return r
 }
 
 // Next returns the next token.Token in l.
-// It returns an error if a string literal does not end with a closing quotation mark.
+// It returns an error if a string literal does not end with a closing quotation mark
+// or contains an unknown escape sequence, or with token.EOF if the Lexer's io.Reader returned an error.
 func (l *Lexer) Next() (token.Token, error) {
 	l.skipWhitespace()
+	l.start = token.Pos{Offset: l.pos, Line: l.line, Col: l.col}
 	var t token.Token
-	switch l.ch {
-	case 0:
-		return token.Token{token.EOF, ""}, nil
-	case '"':
+	switch {
+	case l.ch == 0:
+		return token.Token{token.EOF, ""}, l.err
+	case isQuote(l.ch):
 		l.readChar()
 		lit, err := l.scanString()
 		t = token.Token{token.STRING, lit}
 		if err != nil {
 			return t, err
 		}
-	case '(':
+	case l.ch == '(':
 		t = token.Token{token.LPAREN, "("}
-	case ')':
+	case l.ch == ')':
 		t = token.Token{token.RPAREN, ")"}
-	case '-':
+	case isDash(l.ch):
 		l.readChar()
 		if isNumeral(l.ch) {
-			return token.Token{token.NUMERAL, "-" + l.scan(isNumeral)}, nil
+			return token.Token{token.NUMERAL, "-" + l.scanNumeral()}, nil
 		}
 		return token.Token{token.DASH, "-"}, nil
+	case isLetter(l.ch):
+		lit := l.scanWord()
+		return token.Token{token.Lookup(lit), lit}, nil
+	case isDigit(l.ch):
+		return token.Token{token.NUMERAL, l.scanNumeral()}, nil
 	default:
-		switch {
-		case isLetter(l.ch):
-			lit := l.scan(isLetter)
-			return token.Token{token.Lookup(lit), lit}, nil
-		case isDigit(l.ch):
-			lit := l.scan(isNumeral)
-			return token.Token{token.NUMERAL, lit}, nil
-		default:
-			t = token.Token{token.COMMENT, string(l.ch)}
-		}
+		t = token.Token{token.COMMENT, string(l.ch)}
 	}
 	l.readChar()
 	return t, nil
 }
 
-// scan advances l through all consecutive bytes that satisfy f and returns a string of the bytes read.
-func (l *Lexer) scan(f func(b byte) bool) string {
-	var s string
+// scan advances l through all consecutive characters that satisfy f and returns a string of the characters read.
+func (l *Lexer) scan(f func(r rune) bool) string {
+	start := l.pos
 	for f(l.ch) {
-		s += string(l.ch)
 		l.readChar()
 	}
-	return s
+	return l.text(start)
 }
 
-// scanString advances l through consecutive bytes, stopping at a quotation mark or EOF, and returns a string of the bytes read.
-// It returns errQuote if a closing quotation mark is not found before EOF.
+// scanWord advances l through the letters of a word and any possessive 's that follows them and returns the word,
+// with a typographic apostrophe replaced by a straight one.
+func (l *Lexer) scanWord() string {
+	start := l.pos
+	for isLetter(l.ch) {
+		l.readChar()
+	}
+	if isApostrophe(l.ch) && l.peekChar() == 's' {
+		l.readChar()
+		l.readChar()
+	}
+	return strings.ReplaceAll(l.text(start), "’", "'")
+}
+
+// scanNumeral advances l through the characters of a numeral literal and returns them,
+// with any typographic dashes replaced by hyphen-minus.
+func (l *Lexer) scanNumeral() string {
+	return strings.Map(func(r rune) rune {
+		if isDash(r) {
+			return '-'
+		}
+		return r
+	}, l.scan(isNumeral))
+}
+
+// scanString advances l through the characters of a string literal following its opening quotation mark,
+// stopping at its closing quotation mark or EOF, and returns the string it denotes.
+// Within the literal, two consecutive quotation marks denote the first of them,
+// and a backslash begins an escape sequence: \n denotes a line break, \t a tab,
+// \\ a backslash, and \ followed by a quotation mark that quotation mark.
+// The indentation of a literal that spans multiple lines is normalized as described by dedent.
+// scanString returns ErrQuote if a closing quotation mark is not found before EOF,
+// or ErrEscape if the literal contains an unknown escape sequence.
 func (l *Lexer) scanString() (string, error) {
-	s := l.scan(func(b byte) bool { return b != '"' && b != 0 })
-	if l.ch == 0 {
-		return s, errQuote
+	start := l.pos
+	escaped := false
+	for {
+		switch {
+		case l.ch == 0:
+			s, _ := l.literal(start, escaped)
+			return s, ErrQuote
+		case l.ch == '\\':
+			escaped = true
+			l.readChar()
+			if l.ch != 0 {
+				l.readChar()
+			}
+		case isQuote(l.ch) && isQuote(l.peekChar()):
+			escaped = true
+			l.readChar()
+			l.readChar()
+		case isQuote(l.ch):
+			return l.literal(start, escaped)
+		default:
+			l.readChar()
+		}
 	}
-	return s, nil
+}
+
+// literal returns the string denoted by the text of a string literal from offset start up to pos.
+// If escaped is false, the text contains no escape sequences or doubled quotation marks to replace.
+func (l *Lexer) literal(start int, escaped bool) (string, error) {
+	s := dedent(l.text(start))
+	if !escaped {
+		return s, nil
+	}
+	return unescape(s)
+}
+
+// dedent normalizes the indentation of s, the text of a string literal that spans multiple lines.
+// A line break directly following the opening quotation mark is removed,
+// as is the last line if the closing quotation mark is preceded on its line only by whitespace.
+// The indentation common to the remaining lines, except any text on the line of the opening quotation mark,
+// is removed, disregarding lines that consist only of whitespace, which become empty.
+func dedent(s string) string {
+	if !strings.Contains(s, "\n") {
+		return s
+	}
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	first := 1
+	if isBlank(lines[0]) {
+		lines, first = lines[1:], 0
+	}
+	if n := len(lines); n > 0 && isBlank(lines[n-1]) {
+		lines = lines[:n-1]
+	}
+	var indent string
+	found := false
+	for i := first; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			continue
+		}
+		ws := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+		if !found {
+			indent, found = ws, true
+			continue
+		}
+		for !strings.HasPrefix(ws, indent) {
+			indent = indent[:len(indent)-1]
+		}
+	}
+	for i := first; i < len(lines); i++ {
+		if isBlank(lines[i]) {
+			lines[i] = ""
+		} else {
+			lines[i] = strings.TrimPrefix(lines[i], indent)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isBlank reports whether s consists only of whitespace.
+func isBlank(s string) bool { return strings.TrimSpace(s) == "" }
+
+// unescape returns the string denoted by s, the text of a string literal,
+// replacing doubled quotation marks and escape sequences as described by scanString.
+// An unknown escape sequence is left in place, and unescape returns ErrEscape.
+func unescape(s string) (string, error) {
+	var (
+		b   strings.Builder
+		err error
+	)
+	for i := 0; i < len(s); {
+		r, w := utf8.DecodeRuneInString(s[i:])
+		i += w
+		switch {
+		case r == '\\' && i < len(s):
+			e, ew := utf8.DecodeRuneInString(s[i:])
+			i += ew
+			switch {
+			case e == 'n':
+				b.WriteByte('\n')
+			case e == 't':
+				b.WriteByte('\t')
+			case e == '\\' || isQuote(e):
+				b.WriteRune(e)
+			default:
+				b.WriteRune(r)
+				b.WriteRune(e)
+				err = ErrEscape
+			}
+		case isQuote(r):
+			// The second of a doubled quotation mark
+			b.WriteRune(r)
+			_, qw := utf8.DecodeRuneInString(s[i:])
+			i += qw
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String(), err
 }
 
 func (l *Lexer) skipWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
+	for l.ch != 0 && unicode.IsSpace(l.ch) {
 		l.readChar()
 	}
 }
 
-func isLetter(b byte) bool { return 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' }
+// isLetter reports whether r is a letter in any script.
+func isLetter(r rune) bool { return unicode.IsLetter(r) }
+
+// isDigit reports whether r is an ASCII digit.
+func isDigit(r rune) bool { return '0' <= r && r <= '9' }
 
-// isDigit reports whether b is a digit.
-func isDigit(b byte) bool { return '0' <= b && b <= '9' }
+// isNumeral reports whether r is a valid character for a numeral literal: a digit, a delimiting comma, or a negative sign.
+func isNumeral(r rune) bool { return isDigit(r) || r == ',' || isDash(r) }
 
-// isNumeral reports whether b is a valid character for a numeral literal: a digit, a delimiting comma, or a negative sign.
-func isNumeral(b byte) bool { return isDigit(b) || b == ',' || b == '-' }
+// isQuote reports whether r is a double quotation mark, either straight or typographic.
+func isQuote(r rune) bool { return r == '"' || r == '“' || r == '”' || r == '„' }
+
+// isApostrophe reports whether r is an apostrophe, either straight or typographic.
+func isApostrophe(r rune) bool { return r == '\'' || r == '’' }
+
+// isDash reports whether r is a hyphen-minus or a typographic hyphen, dash, or minus sign.
+func isDash(r rune) bool {
+	switch r {
+	case '-', '‐', '‑', '‒', '–', '—', '−':
+		return true
+	}
+	return false
+}
@@ -1,7 +1,12 @@
 package lexer
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/dkmccandless/assembly/token"
 )
@@ -193,6 +198,65 @@ BE IT RESOLVED that this Assembly directs the Secretary to publish said Greeting
 				{token.IDENT, "Greeting"},
 			},
 		},
+		{
+			input: "WHEREAS the Treasurer Ñuñoa (hereinafter Zoë) is “Greetings — from Ñuñoa”",
+			tokens: []token.Token{
+				{token.WHEREAS, "WHEREAS"},
+				{token.IDENT, "Treasurer"},
+				{token.IDENT, "Ñuñoa"},
+				{token.LPAREN, "("},
+				{token.HEREINAFTER, "hereinafter"},
+				{token.IDENT, "Zoë"},
+				{token.RPAREN, ")"},
+				{token.STRING, "Greetings — from Ñuñoa"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			input: "the Treasurer's Report and the Clerk’s Minutes aren't Members' dues",
+			tokens: []token.Token{
+				{token.IDENT, "Treasurer's"},
+				{token.IDENT, "Report"},
+				{token.IDENT, "Clerk's"},
+				{token.IDENT, "Minutes"},
+				{token.IDENT, "Members"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			input: "fourteen (XIV) the Council (CD) (hereinafter MIX) XIV",
+			tokens: []token.Token{
+				{token.VIGESIMAL, "fourteen"},
+				{token.LPAREN, "("},
+				{token.IDENT, "XIV"},
+				{token.RPAREN, ")"},
+				{token.IDENT, "Council"},
+				{token.LPAREN, "("},
+				{token.IDENT, "CD"},
+				{token.RPAREN, ")"},
+				{token.LPAREN, "("},
+				{token.HEREINAFTER, "hereinafter"},
+				{token.IDENT, "MIX"},
+				{token.RPAREN, ")"},
+				{token.IDENT, "XIV"},
+				{token.EOF, ""},
+			},
+		},
+		{
+			input: "twenty–five twenty—five −3 “curly”\u00a0„low“ ñandú",
+			tokens: []token.Token{
+				{token.TENS, "twenty"},
+				{token.DASH, "-"},
+				{token.ONES, "five"},
+				{token.TENS, "twenty"},
+				{token.DASH, "-"},
+				{token.ONES, "five"},
+				{token.NUMERAL, "-3"},
+				{token.STRING, "curly"},
+				{token.STRING, "low"},
+				{token.EOF, ""},
+			},
+		},
 	}
 	for _, test := range tests {
 		l := New(test.input)
@@ -215,7 +279,7 @@ BE IT RESOLVED that this Assembly directs the Secretary to publish said Greeting
 
 func TestScan(t *testing.T) {
 	for _, test := range []struct {
-		f           func(byte) bool
+		f           func(rune) bool
 		input, want string
 	}{
 		{isLetter, "", ""},
@@ -247,14 +311,24 @@ func TestScanString(t *testing.T) {
 		want    string
 		wanterr error
 	}{
-		{`"`, "", errQuote},
+		{`"`, "", ErrQuote},
 		{`""`, "", nil},
 		{`"a"`, "a", nil},
 		{`"Greetings, Assembly."`, "Greetings, Assembly.", nil},
-		{`"Unterminated quotation`, "Unterminated quotation", errQuote},
+		{`"Unterminated quotation`, "Unterminated quotation", ErrQuote},
+		{`"The Chair ruled ""Out of order."""`, `The Chair ruled "Out of order."`, nil},
+		{`“The Chair ruled ““Out of order.”””`, `The Chair ruled “Out of order.”`, nil},
+		{`"The Chair's 'ruling'"`, `The Chair's 'ruling'`, nil},
+		{`"Item\tAmount\nDues\tone hundred (100)"`, "Item\tAmount\nDues\tone hundred (100)", nil},
+		{`"a backslash \\ and a quotation mark \""`, `a backslash \ and a quotation mark "`, nil},
+		{`"an unknown \q escape"`, `an unknown \q escape`, ErrEscape},
+		{`"escaped \" and ""doubled"" marks`, `escaped " and "doubled" marks`, ErrQuote},
+		{"\"\n\tThe Assembly\n\t  resolved,\n\n\tunanimously.\n\t\"", "The Assembly\n  resolved,\n\nunanimously.", nil},
+		{"\"WHEREAS the Chair\n    ruled;\n  and\n  \"", "WHEREAS the Chair\n  ruled;\nand", nil},
+		{"\"Line one\r\n  Line two\"", "Line one\nLine two", nil},
 	} {
 		l := New(test.input)
-		if l.ch != '"' {
+		if !isQuote(l.ch) {
 			t.Fatalf("ScanString(%v): missing opening quotation mark", test.input)
 		}
 		l.readChar()
@@ -263,3 +337,133 @@ func TestScanString(t *testing.T) {
 		}
 	}
 }
+
+func TestPos(t *testing.T) {
+	input := "Title\n\nWHEREAS the Greeting (hereinafter Greeting) is \"Hello\":\n  BE IT RESOLVED"
+	l := New(input)
+	for _, want := range []token.Pos{
+		{Offset: 0, Line: 1, Col: 1},   // Title
+		{Offset: 7, Line: 3, Col: 1},   // WHEREAS
+		{Offset: 15, Line: 3, Col: 9},  // the
+		{Offset: 19, Line: 3, Col: 13}, // Greeting
+		{Offset: 28, Line: 3, Col: 22}, // (
+		{Offset: 29, Line: 3, Col: 23}, // hereinafter
+		{Offset: 41, Line: 3, Col: 35}, // Greeting
+		{Offset: 49, Line: 3, Col: 43}, // )
+		{Offset: 51, Line: 3, Col: 45}, // is
+		{Offset: 54, Line: 3, Col: 48}, // "Hello"
+		{Offset: 61, Line: 3, Col: 55}, // :
+		{Offset: 65, Line: 4, Col: 3},  // BE
+		{Offset: 68, Line: 4, Col: 6},  // IT
+		{Offset: 71, Line: 4, Col: 9},  // RESOLVED
+		{Offset: 79, Line: 4, Col: 17}, // EOF
+	} {
+		if _, err := l.Next(); err != nil {
+			t.Fatalf("Next(%v): unexpected error: %v", input, err)
+		}
+		if got := l.Pos(); got != want {
+			t.Errorf("Pos(%v): got %+v, want %+v", input, got, want)
+		}
+	}
+}
+
+func TestPosUnicode(t *testing.T) {
+	input := "Zoë “Ñuñoa”\n—"
+	l := New(input)
+	for _, want := range []token.Pos{
+		{Offset: 0, Line: 1, Col: 1},  // Zoë
+		{Offset: 5, Line: 1, Col: 5},  // “Ñuñoa”
+		{Offset: 19, Line: 2, Col: 1}, // —
+		{Offset: 22, Line: 2, Col: 2}, // EOF
+	} {
+		if _, err := l.Next(); err != nil {
+			t.Fatalf("Next(%v): unexpected error: %v", input, err)
+		}
+		if got := l.Pos(); got != want {
+			t.Errorf("Pos(%v): got %+v, want %+v", input, got, want)
+		}
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	for _, input := range []string{
+		"WHEREAS the Total of Dues (hereinafter Total) is one hundred (100)",
+		"Zoë “Ñuñoa”\n—",
+		"\"unterminated",
+		"\"bad \\q escape\"",
+		generate(bufSize/100 + 1),
+	} {
+		for _, r := range []io.Reader{strings.NewReader(input), iotest.OneByteReader(strings.NewReader(input))} {
+			want, got := New(input), NewReader(r)
+			for {
+				wt, werr := want.Next()
+				gt, gerr := got.Next()
+				if gt != wt || gerr != werr || got.Pos() != want.Pos() {
+					t.Fatalf("NewReader(%.20q): got %v, %v at %v; want %v, %v at %v",
+						input, gt, gerr, got.Pos(), wt, werr, want.Pos())
+				}
+				if wt.Typ == token.EOF {
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestNewReaderError(t *testing.T) {
+	errRead := errors.New("read error")
+	l := NewReader(io.MultiReader(strings.NewReader("WHEREAS Total"), iotest.TimeoutReader(failReader{errRead})))
+	for _, want := range []token.Token{
+		{token.WHEREAS, "WHEREAS"},
+		{token.IDENT, "Total"},
+	} {
+		if got, err := l.Next(); got != want || err != nil {
+			t.Fatalf("Next: got %v, %v; want %v, nil", got, err, want)
+		}
+	}
+	if got, err := l.Next(); got.Typ != token.EOF || err != errRead {
+		t.Errorf("Next: got %v, %v; want EOF, %v", got, err, errRead)
+	}
+}
+
+// failReader is an io.Reader that returns err.
+type failReader struct{ err error }
+
+func (r failReader) Read([]byte) (int, error) { return 0, r.err }
+
+// generate returns a machine-generated omnibus resolution of n clauses
+// with long identifiers and string literals.
+func generate(n int) string {
+	var sb strings.Builder
+	sb.WriteString("An Omnibus Resolution\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "WHEREAS the Appropriation (hereinafter AppropriationForTheFiscalYear%c) is %d; and\n", 'A'+i%26, i)
+	}
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&sb, "BE IT RESOLVED that the Secretary shall publish \"%s\"; and\n", strings.Repeat("Item Ñuñoa — ", 100))
+	}
+	return sb.String()
+}
+
+func benchmarkNext(b *testing.B, n int, newLexer func(string) *Lexer) {
+	input := generate(n)
+	b.SetBytes(int64(len(input)))
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l := newLexer(input)
+		for {
+			t, _ := l.Next()
+			if t.Typ == token.EOF {
+				break
+			}
+		}
+	}
+}
+
+func newReader(input string) *Lexer { return NewReader(strings.NewReader(input)) }
+
+func BenchmarkNew1MB(b *testing.B)       { benchmarkNext(b, 1<<10, New) }
+func BenchmarkNew8MB(b *testing.B)       { benchmarkNext(b, 8<<10, New) }
+func BenchmarkNewReader1MB(b *testing.B) { benchmarkNext(b, 1<<10, newReader) }
+func BenchmarkNewReader8MB(b *testing.B) { benchmarkNext(b, 8<<10, newReader) }
@@ -0,0 +1,453 @@
+/*
+Package lsp implements a Language Server Protocol server for Assembly resolutions.
+
+The server communicates over a single stream in each direction, such as standard input and output.
+It publishes the parser's diagnostics for each open document, describes a variable's declaration on hover,
+locates the declaration of a variable from any of its uses, and reports the sites at which a variable is assumed.
+*/
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+)
+
+// errNoShutdown indicates that the client sent an exit notification without first requesting a shutdown.
+var errNoShutdown = errors.New("exit without shutdown")
+
+// Options configure the parsing of documents.
+type Options struct {
+	// Incorporate, if set, permits documents to incorporate other resolutions by reference,
+	// which are read from the file system. Otherwise incorporation by reference is reported as an error.
+	Incorporate bool
+
+	// Dialect holds the conventions by which integers are written in words, both in documents and in hovers.
+	// The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// Lenient, if set, accepts common nonstandard forms of integers, which are published as warnings.
+	Lenient bool
+}
+
+// Serve reads requests from r and writes responses and notifications to w
+// until the client sends an exit notification or r reaches EOF.
+func Serve(r io.Reader, w io.Writer, opts Options) error {
+	s := &server{w: w, opts: opts, docs: make(map[string]*document)}
+	br := bufio.NewReader(r)
+	for {
+		body, err := readMessage(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			if err := s.reply(nil, nil, &responseError{codeParseError, err.Error()}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			if !s.shutdown {
+				return errNoShutdown
+			}
+			return nil
+		}
+		if err := s.handle(&req); err != nil {
+			return err
+		}
+	}
+}
+
+type server struct {
+	w        io.Writer
+	opts     Options
+	docs     map[string]*document
+	shutdown bool
+}
+
+// handle responds to req. It returns an error only if writing to s.w fails.
+func (s *server) handle(req *request) error {
+	if s.shutdown {
+		return s.reply(req.ID, nil, &responseError{codeInvalidRequest, "server is shut down"})
+	}
+	var (
+		result interface{}
+		err    error
+	)
+	switch req.Method {
+	case "initialize":
+		result = map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   1, // full document synchronization
+				"hoverProvider":      true,
+				"definitionProvider": true,
+				"referencesProvider": true,
+			},
+			"serverInfo": map[string]string{"name": "assembly"},
+		}
+	case "shutdown":
+		s.shutdown = true
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			return s.update(params.TextDocument.URI, params.TextDocument.Text)
+		}
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err = json.Unmarshal(req.Params, &params); err == nil && len(params.ContentChanges) > 0 {
+			// With full document synchronization, the last change holds the entire text.
+			return s.update(params.TextDocument.URI, params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			delete(s.docs, params.TextDocument.URI)
+			return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+				URI:         params.TextDocument.URI,
+				Diagnostics: []Diagnostic{},
+			})
+		}
+	case "textDocument/hover":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			if d, ok := s.docs[params.TextDocument.URI]; ok {
+				if h := d.hover(params.Position); h != nil {
+					result = h
+				}
+			}
+		}
+	case "textDocument/definition":
+		var params textDocumentPositionParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			if d, ok := s.docs[params.TextDocument.URI]; ok {
+				if loc := d.definition(params.Position); loc != nil {
+					result = loc
+				}
+			}
+		}
+	case "textDocument/references":
+		var params referenceParams
+		if err = json.Unmarshal(req.Params, &params); err == nil {
+			locs := []Location{}
+			if d, ok := s.docs[params.TextDocument.URI]; ok {
+				locs = d.references(params.Position, params.Context.IncludeDeclaration)
+			}
+			result = locs
+		}
+	default:
+		if req.ID == nil {
+			// Disregard unsupported notifications
+			return nil
+		}
+		return s.reply(req.ID, nil, &responseError{codeMethodNotFound, fmt.Sprintf("method not found: %v", req.Method)})
+	}
+	if req.ID == nil {
+		return nil
+	}
+	if err != nil {
+		return s.reply(req.ID, nil, &responseError{codeInvalidParams, err.Error()})
+	}
+	return s.reply(req.ID, result, nil)
+}
+
+// update records text as the content of the document identified by uri and publishes its diagnostics.
+func (s *server) update(uri, text string) error {
+	d := newDocument(uri, text, s.opts)
+	s.docs[uri] = d
+	return s.notify("textDocument/publishDiagnostics", publishDiagnosticsParams{
+		URI:         uri,
+		Diagnostics: d.diagnostics(),
+	})
+}
+
+// reply sends a response to the request identified by id.
+func (s *server) reply(id *json.RawMessage, result interface{}, rerr *responseError) error {
+	msg := map[string]interface{}{"id": id}
+	if rerr != nil {
+		msg["error"] = rerr
+	} else {
+		msg["result"] = result
+	}
+	return writeMessage(s.w, msg)
+}
+
+// notify sends a notification.
+func (s *server) notify(method string, params interface{}) error {
+	return writeMessage(s.w, map[string]interface{}{"method": method, "params": params})
+}
+
+// A document is an open text document and the result of parsing it.
+type document struct {
+	uri, text string
+	dialect   object.Dialect
+	p         *parser.Parser
+
+	// res is nil if the document does not have the structure of a resolution.
+	res *ast.Resolution
+}
+
+func newDocument(uri, text string, opts Options) *document {
+	d := &document{uri: uri, text: text, dialect: opts.Dialect}
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		d.p = parser.NewFile(filepath.FromSlash(u.Path), lexer.New(text))
+	} else {
+		d.p = parser.New(lexer.New(text))
+	}
+	if opts.Incorporate {
+		d.p.SetReadFile(ioutil.ReadFile)
+	}
+	d.p.SetDialect(opts.Dialect)
+	d.p.SetLenient(opts.Lenient)
+	d.res, _ = d.p.ParseResolution()
+	return d
+}
+
+// inspect calls ast.Inspect on d.res, but does not visit the statements of incorporated resolutions,
+// whose positions lie in other files.
+func (d *document) inspect(f func(ast.Node) bool) {
+	ast.Inspect(d.res, func(n ast.Node) bool {
+		if _, ok := n.(*ast.IncorporationStmt); ok {
+			return false
+		}
+		return f(n)
+	})
+}
+
+func (d *document) diagnostics() []Diagnostic {
+	diags := []Diagnostic{}
+	for _, err := range d.p.Errors() {
+		perr, ok := err.(*parser.Error)
+		if !ok {
+			continue
+		}
+		off := perr.Pos.Offset
+		diags = append(diags, Diagnostic{
+			Range:    d.rangeOf(off, wordEnd(d.text, off)),
+			Severity: severityError,
+			Source:   "assembly",
+			Message:  perr.Err.Error(),
+		})
+	}
+	for _, w := range d.p.Warnings() {
+		diags = append(diags, Diagnostic{
+			Range:    d.rangeOf(w.Pos.Offset, w.End.Offset),
+			Severity: severityWarning,
+			Source:   "assembly",
+			Message:  fmt.Sprintf("%v; write %q", w.Message, w.Suggestion),
+		})
+	}
+	return diags
+}
+
+func (d *document) hover(pos Position) *Hover {
+	id := d.identAt(d.offset(pos))
+	if id == nil {
+		return nil
+	}
+	decl := d.decl(id.Value, d.p.Pos(id).Offset)
+	if decl == nil {
+		return nil
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "**%v**\n", decl.Name.Value)
+	for _, c := range d.p.Clauses() {
+		if c.Stmt == ast.Node(decl) {
+			fmt.Fprintf(&b, "\n```\n%v\n```\n", c.Text(d.text))
+			break
+		}
+	}
+	if decl.Value != nil {
+		fmt.Fprintf(&b, "\nInitial value: %v\n", describe(decl.Value, d.dialect))
+	}
+	return &Hover{
+		Contents: markupContent{Kind: "markdown", Value: b.String()},
+		Range:    d.identRange(id),
+	}
+}
+
+func (d *document) definition(pos Position) *Location {
+	id := d.identAt(d.offset(pos))
+	if id == nil {
+		return nil
+	}
+	decl := d.decl(id.Value, d.p.Pos(id).Offset)
+	if decl == nil {
+		return nil
+	}
+	return &Location{URI: d.uri, Range: d.identRange(decl.Name)}
+}
+
+// references returns the locations of the sites at which the variable at pos is assumed,
+// preceded by the location of its declaration if includeDecl is true.
+// A variable of the same name declared in another scope is a different variable.
+func (d *document) references(pos Position, includeDecl bool) []Location {
+	locs := []Location{}
+	id := d.identAt(d.offset(pos))
+	if id == nil {
+		return locs
+	}
+	decl := d.decl(id.Value, d.p.Pos(id).Offset)
+	if decl != nil && includeDecl {
+		locs = append(locs, Location{URI: d.uri, Range: d.identRange(decl.Name)})
+	}
+	d.inspect(func(n ast.Node) bool {
+		if s, ok := n.(*ast.AssumeStmt); ok && s.Name.Value == id.Value && d.decl(s.Name.Value, d.p.Pos(s.Name).Offset) == decl {
+			locs = append(locs, Location{URI: d.uri, Range: d.identRange(s.Name)})
+		}
+		return true
+	})
+	return locs
+}
+
+// identAt returns the identifier that contains or ends at offset, or nil if there is none.
+func (d *document) identAt(offset int) *ast.Identifier {
+	if d.res == nil {
+		return nil
+	}
+	var found *ast.Identifier
+	d.inspect(func(n ast.Node) bool {
+		if id, ok := n.(*ast.Identifier); ok {
+			if d.p.Pos(id).Offset <= offset && offset <= d.p.End(id).Offset {
+				found = id
+			}
+		}
+		return found == nil
+	})
+	return found
+}
+
+// decl returns the statement that declares name in the innermost scope that contains offset and declares it,
+// or nil if there is none.
+// A Committee of the Whole contains the offsets from its beginning to that of the statement following it,
+// and its declarations shadow those of the same name outside it.
+func (d *document) decl(name string, offset int) *ast.DeclStmt {
+	if d.res == nil {
+		return nil
+	}
+	var found *ast.DeclStmt
+	for _, s := range d.res.WhereasStmts {
+		if decl, ok := s.(*ast.DeclStmt); ok && decl.Name.Value == name {
+			found = decl
+		}
+	}
+	for stmts := d.res.ResolvedStmts; ; {
+		var committee *ast.CommitteeStmt
+		for i, s := range stmts {
+			if c, ok := s.(*ast.CommitteeStmt); ok && d.p.Pos(c).Offset <= offset &&
+				(i+1 == len(stmts) || offset < d.p.Pos(stmts[i+1]).Offset) {
+				committee = c
+			}
+		}
+		if committee == nil {
+			return found
+		}
+		for _, s := range committee.Body {
+			if decl, ok := s.(*ast.DeclStmt); ok && decl.Name.Value == name {
+				found = decl
+			}
+		}
+		stmts = committee.Body
+	}
+}
+
+func (d *document) identRange(id *ast.Identifier) Range {
+	return d.rangeOf(d.p.Pos(id).Offset, d.p.End(id).Offset)
+}
+
+func (d *document) rangeOf(start, end int) Range {
+	return Range{Start: d.position(start), End: d.position(end)}
+}
+
+// position converts a byte offset in d.text to a Position.
+func (d *document) position(offset int) Position {
+	if offset > len(d.text) {
+		offset = len(d.text)
+	}
+	var pos Position
+	lineStart := 0
+	for i := 0; i < offset; i++ {
+		if d.text[i] == '\n' {
+			pos.Line++
+			lineStart = i + 1
+		}
+	}
+	for _, r := range d.text[lineStart:offset] {
+		pos.Character += len(utf16.Encode([]rune{r}))
+	}
+	return pos
+}
+
+// offset converts a Position to a byte offset in d.text.
+func (d *document) offset(pos Position) int {
+	offset := 0
+	for line := 0; line < pos.Line; line++ {
+		i := strings.IndexByte(d.text[offset:], '\n')
+		if i < 0 {
+			return len(d.text)
+		}
+		offset += i + 1
+	}
+	for char := 0; char < pos.Character && offset < len(d.text) && d.text[offset] != '\n'; {
+		r, size := utf8.DecodeRuneInString(d.text[offset:])
+		char += len(utf16.Encode([]rune{r}))
+		offset += size
+	}
+	return offset
+}
+
+// wordEnd returns the offset of the end of the word or quoted string beginning at offset in text.
+func wordEnd(text string, offset int) int {
+	if offset >= len(text) {
+		return len(text)
+	}
+	if text[offset] == '"' {
+		if i := strings.IndexByte(text[offset+1:], '"'); i >= 0 {
+			return offset + i + 2
+		}
+		return len(text)
+	}
+	end := offset
+	for end < len(text) {
+		r, size := utf8.DecodeRuneInString(text[end:])
+		if unicode.IsSpace(r) || end > offset && strings.ContainsRune("(),;:.", r) {
+			break
+		}
+		end += size
+	}
+	if end == offset {
+		end++
+	}
+	return end
+}
+
+// describe returns a description of the value of e, writing integers in dialect.
+func describe(e ast.Expr, dialect object.Dialect) string {
+	switch e := e.(type) {
+	case *ast.IntegerLiteral:
+		return (&object.Integer{Value: e.Value}).InspectIn(dialect)
+	case *ast.StringLiteral:
+		return `"` + e.Value + `"`
+	case *ast.InterpolatedString:
+		return `"` + e.Token.Lit + `"`
+	default:
+		return e.String()
+	}
+}
@@ -0,0 +1,300 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+const testURI = "file:///greeting.asm"
+
+const testText = `A Resolution Concerning Greetings
+
+WHEREAS the Customary Greeting (hereinafter Greeting) is "Hello, World!";
+WHEREAS the Number of Greetings (hereinafter Count) is zero (0): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the aforesaid Greeting; and
+BE IT FURTHER RESOLVED that Count assume the value sum Count one (1); and
+BE IT FURTHER RESOLVED that if Count equals one (1), Count assume the value zero (0).`
+
+// session sends each message in msgs to a Server, followed by a shutdown request and an exit notification,
+// and returns the messages the Server writes in response.
+func session(t *testing.T, msgs ...string) []map[string]interface{} {
+	t.Helper()
+	return sessionWith(t, Options{Incorporate: true}, msgs...)
+}
+
+// sessionWith is like session, but the Server parses documents as opts configure.
+func sessionWith(t *testing.T, opts Options, msgs ...string) []map[string]interface{} {
+	t.Helper()
+	msgs = append(msgs, `{"jsonrpc":"2.0","id":99,"method":"shutdown"}`, `{"jsonrpc":"2.0","method":"exit"}`)
+	var in bytes.Buffer
+	for _, msg := range msgs {
+		fmt.Fprintf(&in, "Content-Length: %d\r\n\r\n%s", len(msg), msg)
+	}
+	var out bytes.Buffer
+	if err := Serve(&in, &out, opts); err != nil {
+		t.Fatalf("Serve: unexpected error: %v", err)
+	}
+	var replies []map[string]interface{}
+	r := bufio.NewReader(&out)
+	for {
+		body, err := readMessage(r)
+		if err != nil {
+			break
+		}
+		var reply map[string]interface{}
+		if err := json.Unmarshal(body, &reply); err != nil {
+			t.Fatalf("Serve: invalid message %s: %v", body, err)
+		}
+		replies = append(replies, reply)
+	}
+	return replies
+}
+
+// didOpen returns a didOpen notification for text.
+func didOpen(text string) string {
+	b, _ := json.Marshal(text)
+	return fmt.Sprintf(`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{"textDocument":{"uri":%q,"languageId":"assembly","version":1,"text":%s}}}`, testURI, b)
+}
+
+// positionRequest returns a request for method at the given line and character.
+func positionRequest(id int, method string, line, char int) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"method":%q,"params":{"textDocument":{"uri":%q},"position":{"line":%d,"character":%d},"context":{"includeDeclaration":true}}}`, id, method, testURI, line, char)
+}
+
+// jsonRange returns the decoded JSON form of a Range.
+func jsonRange(startLine, startChar, endLine, endChar int) interface{} {
+	b, _ := json.Marshal(Range{Position{startLine, startChar}, Position{endLine, endChar}})
+	var v interface{}
+	json.Unmarshal(b, &v)
+	return v
+}
+
+func TestDiagnostics(t *testing.T) {
+	text := "title\nwhereas the Greeting (hereinafter Greeting) is \"Hello\"\nresolved publish Salutation"
+	replies := session(t, didOpen(text))
+	if len(replies) != 2 {
+		t.Fatalf("got %v messages, want 2", len(replies))
+	}
+	if m := replies[0]["method"]; m != "textDocument/publishDiagnostics" {
+		t.Fatalf("got method %v, want textDocument/publishDiagnostics", m)
+	}
+	diags := replies[0]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	for i, want := range []struct {
+		msg string
+		rng interface{}
+	}{
+		{"Salutation undeclared", jsonRange(2, 17, 2, 27)},
+		{"Greeting declared but not used", jsonRange(1, 34, 1, 42)},
+	} {
+		if i >= len(diags) {
+			t.Fatalf("got %v diagnostics, want 2", len(diags))
+		}
+		diag := diags[i].(map[string]interface{})
+		if diag["message"] != want.msg || !reflect.DeepEqual(diag["range"], want.rng) {
+			t.Errorf("diagnostic %v: got %v at %v, want %v at %v", i, diag["message"], diag["range"], want.msg, want.rng)
+		}
+	}
+}
+
+func TestDefinition(t *testing.T) {
+	for _, test := range []struct {
+		line, char int
+		want       interface{}
+	}{
+		{5, 62, jsonRange(2, 44, 2, 52)}, // Greeting in publish
+		{6, 33, jsonRange(3, 45, 3, 50)}, // Count in assume
+		{7, 55, jsonRange(3, 45, 3, 50)}, // Count in if consequence
+		{2, 48, jsonRange(2, 44, 2, 52)}, // Greeting in its own declaration
+		{5, 30, nil},                     // Secretary
+	} {
+		replies := session(t, didOpen(testText), positionRequest(1, "textDocument/definition", test.line, test.char))
+		var got interface{}
+		if loc, ok := replies[1]["result"].(map[string]interface{}); ok {
+			if loc["uri"] != testURI {
+				t.Errorf("definition(%v:%v): got URI %v, want %v", test.line, test.char, loc["uri"], testURI)
+			}
+			got = loc["range"]
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("definition(%v:%v): got %v, want %v", test.line, test.char, got, test.want)
+		}
+	}
+}
+
+func TestHover(t *testing.T) {
+	replies := session(t, didOpen(testText), positionRequest(1, "textDocument/hover", 6, 30))
+	hover, ok := replies[1]["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("hover: got %v, want Hover", replies[1]["result"])
+	}
+	value := hover["contents"].(map[string]interface{})["value"].(string)
+	for _, want := range []string{
+		"**Count**",
+		"WHEREAS the Number of Greetings (hereinafter Count) is zero (0): now, therefore,\n```",
+		"Initial value: zero (0)",
+	} {
+		if !strings.Contains(value, want) {
+			t.Errorf("hover: got %q, want it to contain %q", value, want)
+		}
+	}
+	if got, want := hover["range"], jsonRange(6, 28, 6, 33); !reflect.DeepEqual(got, want) {
+		t.Errorf("hover: got range %v, want %v", got, want)
+	}
+}
+
+func TestReferences(t *testing.T) {
+	replies := session(t, didOpen(testText), positionRequest(1, "textDocument/references", 3, 47))
+	locs, ok := replies[1]["result"].([]interface{})
+	if !ok {
+		t.Fatalf("references: got %v, want locations", replies[1]["result"])
+	}
+	var got []interface{}
+	for _, loc := range locs {
+		got = append(got, loc.(map[string]interface{})["range"])
+	}
+	want := []interface{}{
+		jsonRange(3, 45, 3, 50), // declaration
+		jsonRange(6, 28, 6, 33),
+		jsonRange(7, 53, 7, 58),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("references: got %v, want %v", got, want)
+	}
+}
+
+func TestReferencesSpan(t *testing.T) {
+	// The range of an identifier extends to the end of its last word,
+	// which may follow a line break or a typographic apostrophe.
+	text := "title\nwhereas the balance (hereinafter the Treasurer’s Report) is two (2)\n" +
+		"resolved the Treasurer’s\nReport assume the value three (3)\nresolved publish Treasurer's Report"
+	replies := session(t, didOpen(text), positionRequest(1, "textDocument/references", 1, 40))
+	locs, ok := replies[1]["result"].([]interface{})
+	if !ok {
+		t.Fatalf("references: got %v, want locations", replies[1]["result"])
+	}
+	var got []interface{}
+	for _, loc := range locs {
+		got = append(got, loc.(map[string]interface{})["range"])
+	}
+	want := []interface{}{
+		jsonRange(1, 37, 1, 55), // declaration
+		jsonRange(2, 13, 3, 6),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("references: got %v, want %v", got, want)
+	}
+}
+
+const shadowText = `A Resolution Concerning the Tally
+
+WHEREAS the Running Tally (hereinafter Tally) is zero (0): now, therefore,
+
+BE IT RESOLVED that the Assembly shall resolve itself into a Committee of the Whole, wherein the Interim Tally (hereinafter Tally) is one (1); and
+BE IT FURTHER RESOLVED that the Secretary shall publish Tally; and
+BE IT FURTHER RESOLVED that Tally assume the value Tally squared; and
+BE IT FURTHER RESOLVED that the Committee shall rise; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Tally.`
+
+func TestShadowedDefinition(t *testing.T) {
+	for _, test := range []struct {
+		line, char int
+		want       interface{}
+	}{
+		{5, 58, jsonRange(4, 124, 4, 129)}, // Tally in publish within the Committee
+		{6, 30, jsonRange(4, 124, 4, 129)}, // Tally in assume within the Committee
+		{8, 58, jsonRange(2, 39, 2, 44)},   // Tally in publish after the Committee rises
+	} {
+		replies := session(t, didOpen(shadowText), positionRequest(1, "textDocument/definition", test.line, test.char))
+		var got interface{}
+		if loc, ok := replies[1]["result"].(map[string]interface{}); ok {
+			got = loc["range"]
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("definition(%v:%v): got %v, want %v", test.line, test.char, got, test.want)
+		}
+	}
+
+	for _, test := range []struct {
+		line, char int
+		want       []interface{}
+	}{
+		{2, 41, []interface{}{jsonRange(2, 39, 2, 44)}},
+		{4, 126, []interface{}{jsonRange(4, 124, 4, 129), jsonRange(6, 28, 6, 33)}},
+	} {
+		replies := session(t, didOpen(shadowText), positionRequest(1, "textDocument/references", test.line, test.char))
+		var got []interface{}
+		for _, loc := range replies[1]["result"].([]interface{}) {
+			got = append(got, loc.(map[string]interface{})["range"])
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("references(%v:%v): got %v, want %v", test.line, test.char, got, test.want)
+		}
+	}
+}
+
+func TestDialectOptions(t *testing.T) {
+	text := `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is one hundred and one (101): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the sum of Count and twenty one (21).`
+	replies := sessionWith(t, Options{Dialect: object.British, Lenient: true}, didOpen(text), positionRequest(1, "textDocument/hover", 4, 61))
+	diags := replies[0]["params"].(map[string]interface{})["diagnostics"].([]interface{})
+	if len(diags) != 1 {
+		t.Fatalf("got %v diagnostics, want 1", diags)
+	}
+	diag := diags[0].(map[string]interface{})
+	if want := `nonstandard integer: "twenty one" without a hyphen; write "twenty-one (21)"`; diag["message"] != want || diag["severity"] != float64(severityWarning) {
+		t.Errorf("diagnostic: got %v with severity %v, want %v with severity %v", diag["message"], diag["severity"], want, severityWarning)
+	}
+	if got, want := diag["range"], jsonRange(4, 69, 4, 84); !reflect.DeepEqual(got, want) {
+		t.Errorf("diagnostic: got range %v, want %v", got, want)
+	}
+	hover, ok := replies[1]["result"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("hover: got %v, want Hover", replies[1]["result"])
+	}
+	value := hover["contents"].(map[string]interface{})["value"].(string)
+	if want := "Initial value: one hundred and one (101)"; !strings.Contains(value, want) {
+		t.Errorf("hover: got %q, want it to contain %q", value, want)
+	}
+}
+
+func TestUnknownMethod(t *testing.T) {
+	replies := session(t, `{"jsonrpc":"2.0","id":1,"method":"textDocument/rename","params":{}}`)
+	rerr, ok := replies[0]["error"].(map[string]interface{})
+	if !ok || rerr["code"] != float64(codeMethodNotFound) {
+		t.Errorf("got %v, want error code %v", replies[0], codeMethodNotFound)
+	}
+}
+
+func TestPositionOffset(t *testing.T) {
+	d := &document{text: "ab\n“Zoë” ok\n"}
+	for _, test := range []struct {
+		offset int
+		pos    Position
+	}{
+		{0, Position{0, 0}},
+		{2, Position{0, 2}},
+		{3, Position{1, 0}},
+		{6, Position{1, 1}},  // after “
+		{10, Position{1, 4}}, // after ”
+		{14, Position{1, 6}},
+		{len(d.text), Position{2, 0}},
+	} {
+		if got := d.position(test.offset); got != test.pos {
+			t.Errorf("position(%v): got %v, want %v", test.offset, got, test.pos)
+		}
+		if got := d.offset(test.pos); got != test.offset {
+			t.Errorf("offset(%v): got %v, want %v", test.pos, got, test.offset)
+		}
+	}
+}
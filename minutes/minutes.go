@@ -0,0 +1,149 @@
+/*
+Package minutes records the minutes of the proceedings of an Assembly resolution.
+
+The minutes list each clause in the order in which it is carried out,
+whether the condition of each if statement held or failed,
+when each Committee of the Whole is formed and rises,
+and the old and new values of each variable that changes.
+*/
+package minutes
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/eval"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+)
+
+// A Recorder writes the minutes of a resolution's proceedings as it is evaluated.
+type Recorder struct {
+	w       io.Writer
+	src     string
+	clauses []parser.Clause
+
+	// clause maps each statement that constitutes a clause to its index in clauses.
+	clause map[ast.Node]int
+
+	// old holds the previous value of the variable assigned by the statement being evaluated, if any.
+	old []object.Object
+
+	// item counts the clauses recorded.
+	item int
+
+	// dialect holds the conventions by which integer values are written, those of the attached Evaluator.
+	dialect object.Dialect
+}
+
+// New returns a Recorder that writes to w the minutes of the resolution parsed from src by p.
+func New(w io.Writer, src string, p *parser.Parser) *Recorder {
+	r := &Recorder{
+		w:       w,
+		src:     src,
+		clauses: p.Clauses(),
+		clause:  make(map[ast.Node]int),
+	}
+	for i, c := range r.clauses {
+		if c.Stmt != nil {
+			r.clause[c.Stmt] = i
+		}
+	}
+	fmt.Fprint(w, "MINUTES OF THE ASSEMBLY\n\n")
+	return r
+}
+
+// Attach sets e's hooks to record the proceedings of the resolution as e evaluates it,
+// writing integer values in e's Dialect.
+// Any hooks already set on e are called after the Recorder's.
+func (r *Recorder) Attach(e *eval.Evaluator) {
+	r.dialect = e.Dialect
+	before, after, condition := e.Before, e.After, e.Condition
+	e.Before = func(stmt ast.Node, env *object.Environment) object.Object {
+		r.before(stmt, env)
+		if before != nil {
+			if obj := before(stmt, env); obj != nil {
+				// The halted statement is not evaluated, and after is not called to match r.before.
+				r.old = r.old[:len(r.old)-1]
+				return obj
+			}
+		}
+		return nil
+	}
+	e.After = func(stmt ast.Node, env *object.Environment, result object.Object) {
+		r.after(stmt, env, result)
+		if after != nil {
+			after(stmt, env, result)
+		}
+	}
+	e.Condition = func(stmt *ast.IfStmt, held bool) {
+		r.condition(stmt, held)
+		if condition != nil {
+			condition(stmt, held)
+		}
+	}
+}
+
+// Adjourn records the end of the proceedings and the result of evaluating the resolution.
+func (r *Recorder) Adjourn(result object.Object) {
+	if result != nil {
+		fmt.Fprintf(r.w, "\nThe proceedings were halted: %v\n", result.Inspect())
+		return
+	}
+	fmt.Fprint(r.w, "\nThe resolution having been carried out, the Assembly adjourned.\n")
+}
+
+func (r *Recorder) before(stmt ast.Node, env *object.Environment) {
+	if i, ok := r.clause[stmt]; ok {
+		r.item++
+		c := r.clauses[i]
+		fmt.Fprintf(r.w, "%d. Clause %d (%v): %v\n", r.item, i+1, c, strings.Join(strings.Fields(c.Text(r.src)), " "))
+	}
+	if _, ok := stmt.(*ast.CommitteeStmt); ok {
+		fmt.Fprint(r.w, "\tThe Assembly resolved itself into a Committee of the Whole.\n")
+	}
+	var old object.Object
+	if id := ast.Assignee(stmt); id != nil {
+		old, _ = env.Get(id.Value)
+	}
+	r.old = append(r.old, old)
+}
+
+func (r *Recorder) after(stmt ast.Node, env *object.Environment, result object.Object) {
+	old := r.old[len(r.old)-1]
+	r.old = r.old[:len(r.old)-1]
+	if _, ok := stmt.(*ast.CommitteeStmt); ok && result == nil {
+		fmt.Fprint(r.w, "\tThe Committee rose.\n")
+	}
+	id := ast.Assignee(stmt)
+	if id == nil || result != nil {
+		return
+	}
+	name := id.Value
+	obj, ok := env.Get(name)
+	switch {
+	case !ok:
+	case old == nil:
+		fmt.Fprintf(r.w, "\t%v stands at %v.\n", name, r.inspect(obj))
+	default:
+		fmt.Fprintf(r.w, "\t%v, previously %v, now stands at %v.\n", name, r.inspect(old), r.inspect(obj))
+	}
+}
+
+// inspect returns the text of obj, writing integers in r.dialect.
+func (r *Recorder) inspect(obj object.Object) string {
+	if i, ok := obj.(*object.Integer); ok {
+		return i.InspectIn(r.dialect)
+	}
+	return obj.Inspect()
+}
+
+func (r *Recorder) condition(stmt *ast.IfStmt, held bool) {
+	verdict := "failed"
+	if held {
+		verdict = "held"
+	}
+	fmt.Fprintf(r.w, "\tThe condition that %v %v %v %v.\n", stmt.Left, stmt.Relation.Lit, stmt.Right, verdict)
+}
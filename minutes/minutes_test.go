@@ -0,0 +1,126 @@
+package minutes
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/eval"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+)
+
+func TestRecorder(t *testing.T) {
+	for _, test := range []struct {
+		src, want string
+	}{
+		{
+			`A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is two (2): now, therefore,
+
+BE IT RESOLVED that Count assume the value sum Count one (1);
+BE IT FURTHER RESOLVED that if Count exceeds three (3), the Secretary shall publish "Too many.";
+BE IT FURTHER RESOLVED that this Assembly takes note of the Count;
+BE IT FURTHER RESOLVED that if Count exceeds two (2), Count assume the value twice Count.`,
+			`MINUTES OF THE ASSEMBLY
+
+1. Clause 1 (Whereas clause 1): WHEREAS the Count of Members (hereinafter Count) is two (2): now, therefore,
+	Count stands at two (2).
+2. Clause 2 (Resolved clause 1): RESOLVED that Count assume the value sum Count one (1);
+	Count, previously two (2), now stands at three (3).
+3. Clause 3 (Resolved clause 2): RESOLVED that if Count exceeds three (3), the Secretary shall publish "Too many.";
+	The condition that Count exceeds 3 failed.
+4. Clause 5 (Resolved clause 4): RESOLVED that if Count exceeds two (2), Count assume the value twice Count.
+	The condition that Count exceeds 2 held.
+	Count, previously three (3), now stands at six (6).
+
+The resolution having been carried out, the Assembly adjourned.
+`,
+		},
+		{
+			`A Resolution Concerning Mismatched Types
+
+WHEREAS the Greeting (hereinafter Greeting) is "Hello": now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish twice Greeting.`,
+			`MINUTES OF THE ASSEMBLY
+
+1. Clause 1 (Whereas clause 1): WHEREAS the Greeting (hereinafter Greeting) is "Hello": now, therefore,
+	Greeting stands at Hello.
+2. Clause 2 (Resolved clause 1): RESOLVED that the Secretary shall publish twice Greeting.
+
+The proceedings were halted: non-numeric Hello in numeric context
+`,
+		},
+		{
+			`A Resolution Concerning the Tally
+
+WHEREAS the Number of Members (hereinafter Members) is three (3): now, therefore,
+
+BE IT RESOLVED that the Assembly shall resolve itself into a Committee of the Whole, wherein the Running Tally (hereinafter Tally) is Members squared;
+BE IT FURTHER RESOLVED that Members assume the value sum Tally one (1);
+BE IT FURTHER RESOLVED that the Committee shall rise.`,
+			`MINUTES OF THE ASSEMBLY
+
+1. Clause 1 (Whereas clause 1): WHEREAS the Number of Members (hereinafter Members) is three (3): now, therefore,
+	Members stands at three (3).
+2. Clause 2 (Resolved clause 1): RESOLVED that the Assembly shall resolve itself into a Committee of the Whole, wherein the Running Tally (hereinafter Tally) is Members squared;
+	The Assembly resolved itself into a Committee of the Whole.
+	Tally stands at nine (9).
+3. Clause 3 (Resolved clause 2): RESOLVED that Members assume the value sum Tally one (1);
+	Members, previously three (3), now stands at ten (10).
+	The Committee rose.
+
+The resolution having been carried out, the Assembly adjourned.
+`,
+		},
+	} {
+		p := parser.New(lexer.New(test.src))
+		res, err := p.ParseResolution()
+		if err != nil {
+			t.Fatalf("ParseResolution(%v): unexpected error: %v", test.src, err)
+		}
+		var buf bytes.Buffer
+		r := New(&buf, test.src, p)
+		e := &eval.Evaluator{Out: ioutil.Discard}
+		r.Attach(e)
+		r.Adjourn(e.Eval(res, object.NewEnvironment()))
+		if got := buf.String(); got != test.want {
+			t.Errorf("minutes of %v:\ngot:\n%v\nwant:\n%v", test.src, got, test.want)
+		}
+	}
+}
+
+func TestRecorderHalt(t *testing.T) {
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is two (2): now, therefore,
+
+BE IT RESOLVED that if Count exceeds one (1), Count assume the value twice Count.`
+	p := parser.New(lexer.New(src))
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	halt := &object.Error{Value: "halted"}
+	e := &eval.Evaluator{
+		Out: ioutil.Discard,
+		Before: func(stmt ast.Node, env *object.Environment) object.Object {
+			if _, ok := stmt.(*ast.AssumeStmt); ok {
+				return halt
+			}
+			return nil
+		},
+	}
+	r := New(ioutil.Discard, src, p)
+	r.Attach(e)
+	if obj := e.Eval(res, object.NewEnvironment()); obj != halt {
+		t.Errorf("Eval: got %v, want %v", obj, halt)
+	}
+	if len(r.old) != 0 {
+		t.Errorf("Recorder: %v values remain after evaluation, want 0", len(r.old))
+	}
+}
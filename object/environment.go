@@ -1,12 +1,59 @@
 package object
 
-type Environment struct{ store map[string]Object }
+import "sort"
+
+// An Environment holds the variables of a scope.
+type Environment struct {
+	store map[string]Object
+	outer *Environment
+}
 
 func NewEnvironment() *Environment { return &Environment{store: make(map[string]Object)} }
 
+// NewEnclosedEnvironment returns an Environment enclosed by outer.
+// Its variables shadow any of the same names in outer.
+func NewEnclosedEnvironment(outer *Environment) *Environment {
+	env := NewEnvironment()
+	env.outer = outer
+	return env
+}
+
+// Get returns the value of the variable s in e or the nearest enclosing Environment that defines it.
 func (e *Environment) Get(s string) (Object, bool) {
 	obj, ok := e.store[s]
+	if !ok && e.outer != nil {
+		return e.outer.Get(s)
+	}
 	return obj, ok
 }
 
+// Set defines the variable s in e.
 func (e *Environment) Set(s string, obj Object) { e.store[s] = obj }
+
+// Assign sets the value of the variable s in e or the nearest enclosing Environment that defines it.
+// If none does, Assign defines s in e.
+func (e *Environment) Assign(s string, obj Object) {
+	for env := e; env != nil; env = env.outer {
+		if _, ok := env.store[s]; ok {
+			env.store[s] = obj
+			return
+		}
+	}
+	e.store[s] = obj
+}
+
+// Names returns the names of the variables visible in e in sorted order.
+func (e *Environment) Names() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for env := e; env != nil; env = env.outer {
+		for name := range env.store {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,236 @@
+package object
+
+import (
+	"fmt"
+	"strings"
+)
+
+var (
+	ones      = []string{"", "one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+	vigesimal = []string{"ten", "eleven", "twelve", "thirteen", "fourteen", "fifteen", "sixteen", "seventeen", "eighteen", "nineteen"}
+	tens      = []string{"", "", "twenty", "thirty", "forty", "fifty", "sixty", "seventy", "eighty", "ninety"}
+)
+
+// A Dialect describes the conventions by which integers are written in words.
+// The zero Dialect is American usage, which is also the default.
+type Dialect struct {
+	// And reports whether "and" may separate the tens and units of an integer from its hundreds
+	// or from a greater power, as in "one hundred and one" and "one thousand and five".
+	And bool
+
+	// LongScale reports whether the powers greater than a million are powers of a million,
+	// with "milliard" naming a thousand million and "billion" a million million,
+	// rather than powers of a thousand.
+	LongScale bool
+}
+
+var (
+	// American is the dialect that writes "one hundred one" and "one billion" for 10^9.
+	American = Dialect{}
+
+	// British is the dialect that writes "one hundred and one" and "one billion" for 10^9.
+	British = Dialect{And: true}
+
+	// BritishLongScale is the dialect that writes "one hundred and one" and "one milliard" for 10^9.
+	BritishLongScale = Dialect{And: true, LongScale: true}
+)
+
+var (
+	// shortScale and longScale hold the names of the powers of one thousand (1,000), least first.
+	shortScale = []string{"", "thousand", "million", "billion", "trillion", "quadrillion", "quintillion"}
+	longScale  = []string{"", "thousand", "million", "milliard", "billion", "billiard", "trillion"}
+)
+
+// powers returns the names of the powers of one thousand (1,000) in d, least first.
+func (d Dialect) powers() []string {
+	if d.LongScale {
+		return longScale
+	}
+	return shortScale
+}
+
+// Power returns the value of the power named word in d, such as one thousand (1,000) for "thousand",
+// and reports whether d names such a power.
+func (d Dialect) Power(word string) (int64, bool) {
+	var pow int64 = 1
+	for _, name := range d.powers() {
+		if name == word && pow > 1 {
+			return pow, true
+		}
+		pow *= 1000
+	}
+	return 0, false
+}
+
+// groups returns the magnitudes of the three-digit groups of n, least significant first,
+// and reports whether n is negative.
+func groups(n int64) (gs []int64, negative bool) {
+	negative = n < 0
+	for n != 0 {
+		// Negate n piecewise to avoid overflow in the case of math.MinInt64, for which n == -n
+		r := n % 1000
+		if negative {
+			r *= -1
+		}
+		gs = append(gs, r)
+		n /= 1000
+	}
+	return gs, negative
+}
+
+// Words returns the cardinal form of n in words in American usage, such as "negative twenty-one".
+func Words(n int64) string { return American.Words(n) }
+
+// Words returns the cardinal form of n in words in d, such as "negative twenty-one".
+func (d Dialect) Words(n int64) string {
+	if n == 0 {
+		return "zero"
+	}
+	gs, negative := groups(n)
+	var car string
+	for i := len(gs) - 1; i >= 0; i-- {
+		n := gs[i]
+		if n == 0 {
+			continue
+		}
+		if len(car) > 0 {
+			car += " "
+		}
+		if n >= 100 {
+			car += ones[n/100] + " hundred"
+			n %= 100
+			if n > 0 {
+				car += " "
+				if d.And {
+					car += "and "
+				}
+			}
+		} else if d.And && i == 0 && len(gs) > 1 {
+			car += "and "
+		}
+		switch {
+		case n == 0:
+		case n < 10:
+			car += ones[n]
+		case n < 20:
+			car += vigesimal[n-10]
+		default:
+			car += tens[n/10]
+			if n%10 != 0 {
+				car += "-" + ones[n%10]
+			}
+		}
+		if i > 0 {
+			car += " " + d.powers()[i]
+		}
+	}
+	if negative {
+		car = "negative " + car
+	}
+	return car
+}
+
+// Figures returns the numeral form of n, delimited by commas, such as "-1,121".
+func Figures(n int64) string {
+	if n == 0 {
+		return "0"
+	}
+	gs, negative := groups(n)
+	var num string
+	for i := len(gs) - 1; i >= 0; i-- {
+		if i == len(gs)-1 {
+			num += fmt.Sprintf("%d", gs[i])
+		} else {
+			num += fmt.Sprintf(",%03d", gs[i])
+		}
+	}
+	if negative {
+		num = "-" + num
+	}
+	return num
+}
+
+// Ordinal returns the ordinal form of n in words in American usage followed by its parenthesized numeral,
+// such as "twenty-first (21st)".
+func Ordinal(n int64) string { return American.Ordinal(n) }
+
+// Ordinal returns the ordinal form of n in words in d followed by its parenthesized numeral,
+// such as "twenty-first (21st)".
+func (d Dialect) Ordinal(n int64) string {
+	return fmt.Sprintf("%v (%v%v)", ordinalWords(d.Words(n)), Figures(n), ordinalSuffix(n))
+}
+
+// irregularOrdinals holds the ordinal forms of the cardinal words that are not formed by adding "th".
+var irregularOrdinals = map[string]string{
+	"one":    "first",
+	"two":    "second",
+	"three":  "third",
+	"five":   "fifth",
+	"eight":  "eighth",
+	"nine":   "ninth",
+	"twelve": "twelfth",
+}
+
+// ordinalWords returns the ordinal form of the cardinal car, whose last word takes the ordinal form.
+func ordinalWords(car string) string {
+	i := strings.LastIndexAny(car, " -") + 1
+	last := car[i:]
+	switch {
+	case irregularOrdinals[last] != "":
+		last = irregularOrdinals[last]
+	case strings.HasSuffix(last, "y"):
+		last = strings.TrimSuffix(last, "y") + "ieth"
+	default:
+		last += "th"
+	}
+	return car[:i] + last
+}
+
+// ordinalSuffix returns the suffix of the ordinal numeral of n: "st", "nd", "rd", or "th".
+func ordinalSuffix(n int64) string {
+	d := n % 100
+	if d < 0 {
+		d = -d
+	}
+	switch {
+	case d >= 11 && d <= 13:
+		return "th"
+	case d%10 == 1:
+		return "st"
+	case d%10 == 2:
+		return "nd"
+	case d%10 == 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// romanNumerals holds the values of the Roman numerals and subtractive pairs, in decreasing order.
+var romanNumerals = []struct {
+	value   int64
+	numeral string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"},
+	{100, "C"}, {90, "XC"}, {50, "L"}, {40, "XL"},
+	{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"},
+	{1, "I"},
+}
+
+// MaxRoman is the greatest integer that Roman can represent.
+const MaxRoman = 3999
+
+// Roman returns the Roman numeral of n, such as "XXI", and reports whether n has one,
+// which is the case if n is between one (1) and MaxRoman.
+func Roman(n int64) (string, bool) {
+	if n < 1 || n > MaxRoman {
+		return "", false
+	}
+	var b strings.Builder
+	for _, r := range romanNumerals {
+		for ; n >= r.value; n -= r.value {
+			b.WriteString(r.numeral)
+		}
+	}
+	return b.String(), true
+}
@@ -0,0 +1,135 @@
+package object
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWordsFigures(t *testing.T) {
+	for _, test := range integerTests {
+		i := strings.Index(test.s, " (")
+		words, figures := test.s[:i], test.s[i+2:len(test.s)-1]
+		if got := Words(test.n); got != words {
+			t.Errorf("Words(%v): got %v, want %v", test.n, got, words)
+		}
+		if got := Figures(test.n); got != figures {
+			t.Errorf("Figures(%v): got %v, want %v", test.n, got, figures)
+		}
+	}
+}
+
+func TestDialectWords(t *testing.T) {
+	for _, test := range []struct {
+		d Dialect
+		n int64
+		s string
+	}{
+		{American, 101, "one hundred one"},
+		{British, 101, "one hundred and one"},
+		{British, 100, "one hundred"},
+		{British, 1005, "one thousand and five"},
+		{British, 1100, "one thousand one hundred"},
+		{British, 100021, "one hundred thousand and twenty-one"},
+		{British, 1000105, "one million one hundred and five"},
+		{British, -120, "negative one hundred and twenty"},
+		{British, 1e9, "one billion"},
+		{BritishLongScale, 1e9, "one milliard"},
+		{BritishLongScale, 1e12, "one billion"},
+		{BritishLongScale, 1e15, "one billiard"},
+		{BritishLongScale, 1e18, "one trillion"},
+		{BritishLongScale, 2500000000, "two milliard five hundred million"},
+		{
+			BritishLongScale, math.MaxInt64,
+			"nine trillion two hundred and twenty-three billiard three hundred and seventy-two billion " +
+				"thirty-six milliard eight hundred and fifty-four million seven hundred and seventy-five thousand eight hundred and seven",
+		},
+	} {
+		if got := test.d.Words(test.n); got != test.s {
+			t.Errorf("%+v.Words(%v): got %v, want %v", test.d, test.n, got, test.s)
+		}
+	}
+}
+
+func TestDialectPower(t *testing.T) {
+	for _, test := range []struct {
+		d    Dialect
+		word string
+		pow  int64
+		ok   bool
+	}{
+		{American, "thousand", 1e3, true},
+		{American, "billion", 1e9, true},
+		{American, "quintillion", 1e18, true},
+		{American, "milliard", 0, false},
+		{American, "", 0, false},
+		{BritishLongScale, "thousand", 1e3, true},
+		{BritishLongScale, "milliard", 1e9, true},
+		{BritishLongScale, "billion", 1e12, true},
+		{BritishLongScale, "trillion", 1e18, true},
+		{BritishLongScale, "quadrillion", 0, false},
+	} {
+		if pow, ok := test.d.Power(test.word); pow != test.pow || ok != test.ok {
+			t.Errorf("%+v.Power(%q): got %v, %v; want %v, %v", test.d, test.word, pow, ok, test.pow, test.ok)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	for _, test := range []struct {
+		n int64
+		s string
+	}{
+		{0, "zeroth (0th)"},
+		{1, "first (1st)"},
+		{2, "second (2nd)"},
+		{3, "third (3rd)"},
+		{4, "fourth (4th)"},
+		{5, "fifth (5th)"},
+		{8, "eighth (8th)"},
+		{9, "ninth (9th)"},
+		{11, "eleventh (11th)"},
+		{12, "twelfth (12th)"},
+		{13, "thirteenth (13th)"},
+		{20, "twentieth (20th)"},
+		{21, "twenty-first (21st)"},
+		{42, "forty-second (42nd)"},
+		{100, "one hundredth (100th)"},
+		{111, "one hundred eleventh (111th)"},
+		{1003, "one thousand third (1,003rd)"},
+		{1000000, "one millionth (1,000,000th)"},
+		{-1, "negative first (-1st)"},
+		{-12, "negative twelfth (-12th)"},
+	} {
+		if got := Ordinal(test.n); got != test.s {
+			t.Errorf("Ordinal(%v): got %v, want %v", test.n, got, test.s)
+		}
+	}
+}
+
+func TestRoman(t *testing.T) {
+	for _, test := range []struct {
+		n  int64
+		s  string
+		ok bool
+	}{
+		{1, "I", true},
+		{4, "IV", true},
+		{9, "IX", true},
+		{14, "XIV", true},
+		{40, "XL", true},
+		{90, "XC", true},
+		{400, "CD", true},
+		{1994, "MCMXCIV", true},
+		{2024, "MMXXIV", true},
+		{MaxRoman, "MMMCMXCIX", true},
+		{0, "", false},
+		{-1, "", false},
+		{MaxRoman + 1, "", false},
+		{math.MinInt64, "", false},
+	} {
+		if s, ok := Roman(test.n); s != test.s || ok != test.ok {
+			t.Errorf("Roman(%v): got %v, %v; want %v, %v", test.n, s, ok, test.s, test.ok)
+		}
+	}
+}
@@ -2,9 +2,12 @@ package parser
 
 import (
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/object"
 	"github.com/dkmccandless/assembly/token"
 )
 
@@ -20,6 +23,9 @@ var (
 
 	// errDisagree indicates that a cardinal and the following numeral represent different numbers.
 	errDisagree = errors.New("cardinal and numeral disagree")
+
+	// errRoman indicates that an integer's Roman numeral part is not a Roman numeral in canonical form.
+	errRoman = errors.New("invalid Roman numeral")
 )
 
 func (p *Parser) parseIntegerLiteral() ast.Expr {
@@ -28,6 +34,11 @@ func (p *Parser) parseIntegerLiteral() ast.Expr {
 		return nil
 	}
 
+	pos := p.curPos
+	p.nonstandard = nil
+	if p.atArticle() {
+		p.next()
+	}
 	c, err := p.parseCardinalLiteral()
 	if err != nil {
 		p.error(err)
@@ -35,18 +46,27 @@ func (p *Parser) parseIntegerLiteral() ast.Expr {
 	}
 
 	if !p.peekIs(token.LPAREN) {
-		p.error(errInteger)
-		return nil
+		if p.peek.IsCardinal() || !p.tolerate("missing numeral") {
+			p.error(errInteger)
+			return nil
+		}
+		return p.integerLiteral(c, pos, false)
 	}
 	p.next()
 
-	if !p.peekIs(token.NUMERAL) && !p.peekIs(token.DASH) {
+	// A word that consists only of the letters of Roman numerals, such as "XIV", is taken as a Roman numeral.
+	roman := p.peekIs(token.IDENT) && isRomanNumeral(p.peek.Lit)
+	if !p.peekIs(token.NUMERAL) && !p.peekIs(token.DASH) && !roman {
 		p.error(errInteger)
 		return nil
 	}
 	p.next()
 
-	n, err := p.parseNumeralLiteral()
+	parseNumeral := p.parseNumeralLiteral
+	if roman {
+		parseNumeral = p.parseRomanNumeral
+	}
+	n, err := parseNumeral()
 	if err != nil {
 		p.error(err)
 		return nil
@@ -63,9 +83,38 @@ func (p *Parser) parseIntegerLiteral() ast.Expr {
 		return nil
 	}
 
+	return p.integerLiteral(n, pos, roman)
+}
+
+// integerLiteral returns an IntegerLiteral with value n that begins at pos and ends with p.cur.
+// If the literal was written in a nonstandard form, it records a Warning suggesting the canonical form,
+// whose numeral is a Roman numeral if roman is true.
+func (p *Parser) integerLiteral(n int64, pos token.Pos, roman bool) *ast.IntegerLiteral {
+	if len(p.nonstandard) > 0 {
+		num := object.Figures(n)
+		if r, ok := object.Roman(n); ok && roman {
+			num = r
+		}
+		p.warnings = append(p.warnings, Warning{
+			Pos:        pos,
+			End:        p.curEnd,
+			Message:    "nonstandard integer: " + strings.Join(p.nonstandard, ", "),
+			Suggestion: fmt.Sprintf("%v (%v)", p.dialect.Words(n), num),
+		})
+		p.nonstandard = nil
+	}
 	return &ast.IntegerLiteral{Token: token.Token{token.INTEGER, strconv.Itoa(int(n))}, Value: n}
 }
 
+// tolerate reports whether p is lenient, and if so, records that the integer literal being parsed
+// is nonstandard in the way that what describes.
+func (p *Parser) tolerate(what string) bool {
+	if p.lenient {
+		p.nonstandard = append(p.nonstandard, what)
+	}
+	return p.lenient
+}
+
 func (p *Parser) parseCardinalLiteral() (int64, error) {
 	if p.curIs(token.ZERO) {
 		return 0, nil
@@ -78,11 +127,34 @@ func (p *Parser) parseCardinalLiteral() (int64, error) {
 		p.next()
 	}
 
+	if n, ok := multiples[p.cur.Lit]; ok && p.curIs(token.COMMENT) {
+		if !p.tolerate(fmt.Sprintf("%q in place of %q", p.cur.Lit, p.dialect.Words(n))) {
+			return 0, errCardinal
+		}
+		if negative {
+			n *= -1
+		}
+		return n, nil
+	}
+	switch p.cur.Typ {
+	case token.HUNDRED, token.POWER:
+		// "a hundred" stands for "one hundred"
+		if !p.tolerate(fmt.Sprintf("%q without %q", p.cur.Lit, "one")) {
+			return 0, errCardinal
+		}
+		p.implyOne()
+	}
+
+	// and records that the current group follows "and", and so must be the last and less than one hundred (100)
+	var and bool
 	for {
 		td, err := p.parseThreeDigitCardinal()
 		if err != nil {
 			return 0, err
 		}
+		if and && (td >= 100 || p.peekIs(token.POWER)) {
+			return 0, errCardinal
+		}
 		if negative {
 			td *= -1
 		}
@@ -90,7 +162,10 @@ func (p *Parser) parseCardinalLiteral() (int64, error) {
 		var pow int64 = 1
 		if p.peekIs(token.POWER) {
 			p.next()
-			pow = value[p.cur.Lit]
+			var ok bool
+			if pow, ok = p.dialect.Power(p.cur.Lit); !ok {
+				return 0, errCardinal
+			}
 		}
 
 		// Check for direct overflow
@@ -110,7 +185,10 @@ func (p *Parser) parseCardinalLiteral() (int64, error) {
 			return 0, errCardinal
 		}
 
-		if pow == 1 || !p.peekIs(token.ONES) && !p.peekIs(token.VIGESIMAL) && !p.peekIs(token.TENS) {
+		if pow == 1 {
+			return n, nil
+		}
+		if and = p.skipAnd(); !and && !p.peekIsCardinal() {
 			return n, nil
 		}
 		p.next()
@@ -124,7 +202,7 @@ func (p *Parser) parseThreeDigitCardinal() (int64, error) {
 		if p.peek.Typ == token.HUNDRED {
 			p.next()
 			n *= 100
-			if p.peekIs(token.ONES) || p.peekIs(token.VIGESIMAL) || p.peekIs(token.TENS) {
+			if p.skipAnd() || p.peekIsCardinal() {
 				p.next()
 				td, err := p.parseTwoDigitCardinal()
 				if err != nil {
@@ -141,6 +219,49 @@ func (p *Parser) parseThreeDigitCardinal() (int64, error) {
 	}
 }
 
+// peekIsCardinal reports whether p.peek begins a cardinal of less than one thousand (1,000).
+func (p *Parser) peekIsCardinal() bool {
+	return p.peekIs(token.ONES) || p.peekIs(token.VIGESIMAL) || p.peekIs(token.TENS)
+}
+
+// skipAnd consumes p.peek and reports true if it is an "and" that separates
+// the tens and units of a cardinal from its hundreds or from a greater power,
+// which must be followed by tens or units and which p.dialect permits or p tolerates.
+func (p *Parser) skipAnd() bool {
+	if !p.phraseAt(1, "and") {
+		return false
+	}
+	switch p.lookahead(1).Typ {
+	case token.ONES, token.VIGESIMAL, token.TENS:
+	default:
+		return false
+	}
+	if !p.dialect.And && !p.tolerate(`"and" in American usage`) {
+		return false
+	}
+	p.next()
+	return true
+}
+
+// atArticle reports whether p is lenient and p.cur is the article that begins an integer literal
+// such as "a dozen" or "a hundred".
+func (p *Parser) atArticle() bool {
+	if !p.lenient || !p.curIs(token.COMMENT) || p.cur.Lit != "a" {
+		return false
+	}
+	_, ok := multiples[p.peek.Lit]
+	return p.peekIs(token.HUNDRED) || p.peekIs(token.POWER) || ok && p.peekIs(token.COMMENT)
+}
+
+// implyOne replaces p.cur, a "hundred" or a power that begins a cardinal as in "a hundred",
+// with an implied "one", which p.cur then precedes.
+func (p *Parser) implyOne() {
+	p.ahead = append([]positioned{{p.peek, p.peekPos, p.peekEnd}}, p.ahead...)
+	p.peek, p.peekPos, p.peekEnd = p.cur, p.curPos, p.curEnd
+	// The implied "one" occupies no text.
+	p.cur, p.curEnd = token.Token{Typ: token.ONES, Lit: "one"}, p.curPos
+}
+
 func (p *Parser) parseTwoDigitCardinal() (int64, error) {
 	switch val := value[p.cur.Lit]; p.cur.Typ {
 	case token.ONES, token.VIGESIMAL:
@@ -154,6 +275,9 @@ func (p *Parser) parseTwoDigitCardinal() (int64, error) {
 			}
 			p.next()
 			n += value[p.cur.Lit]
+		} else if p.peekIs(token.ONES) && p.tolerate(fmt.Sprintf("%q without a hyphen", p.cur.Lit+" "+p.peek.Lit)) {
+			p.next()
+			n += value[p.cur.Lit]
 		}
 		return n, nil
 	default:
@@ -162,40 +286,69 @@ func (p *Parser) parseTwoDigitCardinal() (int64, error) {
 }
 
 var value = map[string]int64{
-	"one":         1,
-	"two":         2,
-	"three":       3,
-	"four":        4,
-	"five":        5,
-	"six":         6,
-	"seven":       7,
-	"eight":       8,
-	"nine":        9,
-	"ten":         10,
-	"eleven":      11,
-	"twelve":      12,
-	"thirteen":    13,
-	"fourteen":    14,
-	"fifteen":     15,
-	"sixteen":     16,
-	"seventeen":   17,
-	"eighteen":    18,
-	"nineteen":    19,
-	"twenty":      20,
-	"thirty":      30,
-	"forty":       40,
-	"fifty":       50,
-	"sixty":       60,
-	"seventy":     70,
-	"eighty":      80,
-	"ninety":      90,
-	"hundred":     100,
-	"thousand":    1e3,
-	"million":     1e6,
-	"billion":     1e9,
-	"trillion":    1e12,
-	"quadrillion": 1e15,
-	"quintillion": 1e18,
+	"one":       1,
+	"two":       2,
+	"three":     3,
+	"four":      4,
+	"five":      5,
+	"six":       6,
+	"seven":     7,
+	"eight":     8,
+	"nine":      9,
+	"ten":       10,
+	"eleven":    11,
+	"twelve":    12,
+	"thirteen":  13,
+	"fourteen":  14,
+	"fifteen":   15,
+	"sixteen":   16,
+	"seventeen": 17,
+	"eighteen":  18,
+	"nineteen":  19,
+	"twenty":    20,
+	"thirty":    30,
+	"forty":     40,
+	"fifty":     50,
+	"sixty":     60,
+	"seventy":   70,
+	"eighty":    80,
+	"ninety":    90,
+	"hundred":   100,
+}
+
+// romanValue holds the values of the letters of Roman numerals.
+var romanValue = map[rune]int64{'I': 1, 'V': 5, 'X': 10, 'L': 50, 'C': 100, 'D': 500, 'M': 1000}
+
+// parseRomanNumeral parses a Roman numeral such as "XIV", which must be in canonical form:
+// the form in which object.Roman writes its value.
+func (p *Parser) parseRomanNumeral() (int64, error) {
+	num := p.cur.Lit
+	var n int64
+	for i, r := range num {
+		v := romanValue[r]
+		if v == 0 {
+			return 0, errRoman
+		}
+		// A letter is subtracted if it precedes a letter of greater value.
+		if i+1 < len(num) && v < romanValue[rune(num[i+1])] {
+			v = -v
+		}
+		n += v
+	}
+	if canonical, ok := object.Roman(n); !ok || canonical != num {
+		return 0, errRoman
+	}
+	return n, nil
+}
+
+// isRomanNumeral reports whether s consists only of the uppercase letters of Roman numerals.
+func isRomanNumeral(s string) bool {
+	for _, r := range s {
+		if !strings.ContainsRune("IVXLCDM", r) {
+			return false
+		}
+	}
+	return true
 }
 
 func (p *Parser) parseNumeralLiteral() (int64, error) {
@@ -248,3 +401,9 @@ func (p *Parser) parseNumeralLiteral() (int64, error) {
 
 	return n, nil
 }
+
+// multiples holds the values of the nouns that lenient mode accepts in place of a cardinal, as in "a dozen".
+var multiples = map[string]int64{
+	"dozen": 12,
+	"score": 20,
+}
@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"math"
 	"reflect"
 	"strconv"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/dkmccandless/assembly/ast"
 	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
 	"github.com/dkmccandless/assembly/token"
 )
 
@@ -345,6 +347,164 @@ func TestParseIntegerLiteral(t *testing.T) {
 	}
 }
 
+func TestParseRomanIntegerLiteral(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		n     int64
+	}{
+		{"one (I)", 1},
+		{"four (IV)", 4},
+		{"fourteen (XIV)", 14},
+		{"forty-nine (XLIX)", 49},
+		{"ninety-nine (XCIX)", 99},
+		{"four hundred forty-four (CDXLIV)", 444},
+		{"one thousand nine hundred ninety-four (MCMXCIV)", 1994},
+		{"three thousand nine hundred ninety-nine (MMMCMXCIX)", 3999},
+	} {
+		want := &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: strconv.Itoa(int(test.n))}, Value: test.n}
+		p := New(lexer.New(test.input))
+		got := p.parseIntegerLiteral()
+		err := p.lastError()
+		if !reflect.DeepEqual(got, want) || err != nil {
+			t.Errorf("parseIntegerLiteral(%v): got %v, %v; want %v", test.input, got, err, test.n)
+		}
+	}
+}
+
+func TestParseDialectIntegerLiteral(t *testing.T) {
+	for _, d := range []object.Dialect{object.American, object.British, object.BritishLongScale} {
+		for _, n := range []int64{
+			0, 1, 21, 100, 101, 120, 999, 1005, 1100, 100021, 1000105, 2500000000, 1e12, 1e15 + 1,
+			-120, math.MaxInt64, math.MinInt64,
+		} {
+			input := fmt.Sprintf("%v (%v)", d.Words(n), object.Figures(n))
+			want := &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: strconv.Itoa(int(n))}, Value: n}
+			p := New(lexer.New(input))
+			p.SetDialect(d)
+			got := p.parseIntegerLiteral()
+			err := p.lastError()
+			if !reflect.DeepEqual(got, want) || err != nil {
+				t.Errorf("%+v: parseIntegerLiteral(%v): got %v, %v; want %v", d, input, got, err, n)
+			}
+		}
+	}
+}
+
+func TestParseInvalidDialectIntegerLiteral(t *testing.T) {
+	for _, test := range []struct {
+		d     object.Dialect
+		input string
+		want  error
+	}{
+		{object.American, "one hundred and one (101)", errInteger},                            // "and" is British
+		{object.American, "one milliard (1,000,000,000)", errCardinal},                        // milliard is long scale
+		{object.British, "one milliard (1,000,000,000)", errCardinal},                         // ...only
+		{object.BritishLongScale, "one quintillion (1,000,000,000,000,000,000)", errCardinal}, // quintillion is short scale
+		{object.BritishLongScale, "one billion (1,000,000,000)", errDisagree},                 // billion is 10^12 in the long scale
+		{object.British, "one thousand and two hundred (1,200)", errCardinal},                 // "and" precedes only tens and units...
+		{object.British, "one thousand and one million (1,001,000)", errCardinal},             // ...of the last group
+		{object.British, "one million and one thousand (1,001,000)", errCardinal},             // ...without a power
+		{object.British, "one hundred and (100)", errInteger},                                 // "and" must be followed by tens or units
+		{object.British, "one hundred and one (100)", errDisagree},                            // cardinal and numeral must still agree
+		{object.BritishLongScale, "ten trillion (10,000,000,000,000,000,000)", errCardinal},   // overflow
+	} {
+		p := New(lexer.New(test.input))
+		p.SetDialect(test.d)
+		got := p.parseIntegerLiteral()
+		err := p.lastError()
+		if got != nil || err != test.want {
+			t.Errorf("%+v: parseIntegerLiteral(%v): got %v, %v; want %v", test.d, test.input, got, err, test.want)
+		}
+	}
+}
+
+func TestParseLenientIntegerLiteral(t *testing.T) {
+	for _, test := range []struct {
+		d          object.Dialect
+		input      string
+		n          int64
+		message    string
+		suggestion string
+	}{
+		{object.American, "twenty one (21)", 21, `nonstandard integer: "twenty one" without a hyphen`, "twenty-one (21)"},
+		{object.American, "hundred (100)", 100, `nonstandard integer: "hundred" without "one"`, "one hundred (100)"},
+		{object.American, "a hundred (100)", 100, `nonstandard integer: "hundred" without "one"`, "one hundred (100)"},
+		{object.American, "a thousand (1,000)", 1000, `nonstandard integer: "thousand" without "one"`, "one thousand (1,000)"},
+		{object.American, "a dozen (12)", 12, `nonstandard integer: "dozen" in place of "twelve"`, "twelve (12)"},
+		{object.American, "a score (XX)", 20, `nonstandard integer: "score" in place of "twenty"`, "twenty (XX)"},
+		{object.American, "a dozen", 12, `nonstandard integer: "dozen" in place of "twelve", missing numeral`, "twelve (12)"},
+		{object.American, "one thousand and five (1,005)", 1005, `nonstandard integer: "and" in American usage`, "one thousand five (1,005)"},
+		{object.American, "one hundred and one (101)", 101, `nonstandard integer: "and" in American usage`, "one hundred one (101)"},
+		{object.American, "forty-two", 42, "nonstandard integer: missing numeral", "forty-two (42)"},
+		{
+			object.American, "hundred twenty one", 121,
+			`nonstandard integer: "hundred" without "one", "twenty one" without a hyphen, missing numeral`, "one hundred twenty-one (121)",
+		},
+		{
+			object.British, "hundred and twenty one (121)", 121,
+			`nonstandard integer: "hundred" without "one", "twenty one" without a hyphen`, "one hundred and twenty-one (121)",
+		},
+		{object.British, "one thousand and five (1,005)", 1005, "", ""}, // standard in the British dialect
+		{object.American, "twenty-one (21)", 21, "", ""},
+	} {
+		want := &ast.IntegerLiteral{Token: token.Token{Typ: token.INTEGER, Lit: strconv.Itoa(int(test.n))}, Value: test.n}
+		var wantWarnings []Warning
+		if test.message != "" {
+			wantWarnings = []Warning{{
+				Pos:        token.Pos{Offset: 0, Line: 1, Col: 1},
+				End:        token.Pos{Offset: len(test.input), Line: 1, Col: len(test.input) + 1},
+				Message:    test.message,
+				Suggestion: test.suggestion,
+			}}
+		}
+
+		p := New(lexer.New(test.input))
+		p.SetDialect(test.d)
+		p.SetLenient(true)
+		got := p.parseIntegerLiteral()
+		err := p.lastError()
+		if !reflect.DeepEqual(got, want) || err != nil {
+			t.Errorf("lenient parseIntegerLiteral(%v): got %v, %v; want %v", test.input, got, err, test.n)
+		}
+		if ws := p.Warnings(); !reflect.DeepEqual(ws, wantWarnings) {
+			t.Errorf("lenient parseIntegerLiteral(%v): got warnings %v, want %v", test.input, ws, wantWarnings)
+		}
+
+		// Strict mode rejects each nonstandard form.
+		if test.message == "" {
+			continue
+		}
+		p = New(lexer.New(test.input))
+		p.SetDialect(test.d)
+		if got := p.parseIntegerLiteral(); got != nil || p.lastError() == nil {
+			t.Errorf("strict parseIntegerLiteral(%v): got %v, %v; want error", test.input, got, p.lastError())
+		}
+	}
+}
+
+func TestParseInvalidLenientIntegerLiteral(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  error
+	}{
+		{"twenty one hundred (2,100)", errInteger},            // a cardinal that does not end the literal is not a missing numeral
+		{"one thousand and two hundred (1,200)", errCardinal}, // "and" precedes only tens and units
+		{"twenty one (22)", errDisagree},                      // cardinal and numeral must still agree
+		{"a dozen (13)", errDisagree},
+	} {
+		p := New(lexer.New(test.input))
+		p.SetLenient(true)
+		got := p.parseIntegerLiteral()
+		err := p.lastError()
+		if got != nil || err != test.want {
+			t.Errorf("lenient parseIntegerLiteral(%v): got %v, %v; want %v", test.input, got, err, test.want)
+		}
+		if ws := p.Warnings(); len(ws) != 0 {
+			t.Errorf("lenient parseIntegerLiteral(%v): got warnings %v, want none", test.input, ws)
+		}
+	}
+}
+
 func TestParseInvalidIntegerLiteral(t *testing.T) {
 	for _, test := range []struct {
 		input string
@@ -358,6 +518,13 @@ func TestParseInvalidIntegerLiteral(t *testing.T) {
 		{"three ($3)", errInteger},                      // parentheses may only contain a numeral
 		{"negative one (1)", errDisagree},               // parity of cardinal and numeral must agree
 		{"three hundred sixty-five (366)", errDisagree}, // magnitude of cardinal and numeral must agree
+		{"fourteen (XV)", errDisagree},                  // Roman numerals must agree too
+		{"negative one (I)", errDisagree},               // Roman numerals are never negative
+		{"four (IIII)", errRoman},                       // Roman numerals must be canonical...
+		{"ninety-nine (IC)", errRoman},                  // ...without improper subtraction
+		{"nineteen (XVIIII)", errRoman},                 // ...or improper repetition
+		{"ten (VV)", errRoman},                          // of any letter
+		{"four thousand (MMMM)", errRoman},              // ...and in range
 	} {
 		p := New(lexer.New(test.input))
 		got := p.parseIntegerLiteral()
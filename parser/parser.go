@@ -3,12 +3,78 @@ package parser
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/dkmccandless/assembly/ast"
 	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
 	"github.com/dkmccandless/assembly/token"
 )
 
+// An Error is a parsing error together with the position in the source at which it occurred.
+type Error struct {
+	Pos token.Pos
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string { return fmt.Sprintf("%v: %v", e.Pos, e.Err) }
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error { return e.Err }
+
+// Code returns a short, stable identifier for the kind of e, such as "undeclared",
+// suitable for processing by programs.
+func (e *Error) Code() string {
+	switch err := e.Err.(type) {
+	case redeclaredError:
+		return "redeclared"
+	case undeclaredError:
+		return "undeclared"
+	case unusedError:
+		return "unused"
+	case notFunctionError:
+		return "not-function"
+	case unrecognizedError:
+		return "unrecognized-" + err.what
+	case incorporationError:
+		return "incorporation"
+	case cycleError:
+		return "incorporation-cycle"
+	}
+	if code, ok := codes[e.Err]; ok {
+		return code
+	}
+	return "syntax"
+}
+
+// codes maps each sentinel error to its code.
+var codes = map[error]string{
+	lexer.ErrQuote:   "no-closing-quote",
+	lexer.ErrEscape:  "invalid-escape",
+	errNoTitle:       "no-title",
+	errEarlyResolved: "early-resolved",
+	errLateWhereas:   "late-whereas",
+	errNoResolved:    "no-resolved",
+	errNoWhereas:     "no-whereas",
+	errIncomplete:    "incomplete",
+	errResolvedDecl:  "resolved-declaration",
+	errConditional:   "conditional",
+	errNoCommittee:   "no-committee",
+	errNoRise:        "no-rise",
+	errNoPath:        "no-path",
+	errNoOpen:        "incorporation-disabled",
+	errNoForegoing:   "no-foregoing",
+	errNoRecorded:    "no-recorded",
+	errInteger:       "invalid-integer",
+	errCardinal:      "invalid-cardinal",
+	errNumeral:       "invalid-numeral",
+	errDisagree:      "cardinal-numeral-disagree",
+	errRoman:         "invalid-roman-numeral",
+}
+
 // ErrorList is a list of parsing errors.
 // The zero value is an empty ErrorList ready to use.
 type ErrorList []error
@@ -36,6 +102,24 @@ func (el ErrorList) Error() string {
 	}
 }
 
+// A Warning describes a nonstandard construct that the parser accepted in lenient mode.
+type Warning struct {
+	// Pos and End are the positions of the first character of the construct, including any article,
+	// and of the character following it.
+	Pos, End token.Pos
+
+	// Message describes what is nonstandard, such as `nonstandard integer: "twenty one" without a hyphen`.
+	Message string
+
+	// Suggestion is the canonical text that may replace the construct, such as "twenty-one (21)".
+	Suggestion string
+}
+
+// String returns a description of w such as `5:21: nonstandard integer: missing numeral; write "twenty-one (21)"`.
+func (w Warning) String() string {
+	return fmt.Sprintf("%v: %v; write %q", w.Pos, w.Message, w.Suggestion)
+}
+
 type usage int
 
 const (
@@ -49,30 +133,157 @@ type precedence int
 const (
 	LOWEST precedence = iota
 	INFIX
+	EXPONENT
 	PREFIX
 	POSTFIX
 )
 
+// A Clause records the extent of a Whereas or Resolved clause and the statement it contains.
+type Clause struct {
+	Token token.Token // token.WHEREAS or token.RESOLVED
+
+	// N is the number of the clause among clauses of the same kind, counting from one (1).
+	N int
+
+	// Pos is the position of Token, and End is the position of the token following the clause.
+	Pos, End token.Pos
+
+	// Stmt is the statement contained in the clause, or nil if there is none.
+	Stmt ast.Node
+}
+
+// String returns a description of c such as "Resolved clause 2".
+func (c Clause) String() string {
+	if c.Token.Typ == token.WHEREAS {
+		return fmt.Sprintf("Whereas clause %d", c.N)
+	}
+	return fmt.Sprintf("Resolved clause %d", c.N)
+}
+
+// Text returns the text of c in src, the source from which c was parsed,
+// excluding any text on the line of the following clause, such as "BE IT FURTHER".
+func (c Clause) Text(src string) string {
+	text := src[c.Pos.Offset:c.End.Offset]
+	if i := strings.LastIndexByte(text, '\n'); i >= 0 && c.End.Offset < len(src) {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text)
+}
+
 // Parser parses tokens from a Lexer into an abstract syntax tree.
 type Parser struct {
 	l      *lexer.Lexer
 	errors ErrorList
 
-	// idents contains all declared identifiers and records whether each has been used.
+	// filename is the name of the file being parsed, if known.
+	filename string
+
+	// incorporating holds the names of the files that incorporate the file being parsed, outermost first.
+	incorporating []string
+
+	// readFile reads the files of resolutions incorporated by reference, or is nil if incorporation is not enabled.
+	readFile func(name string) ([]byte, error)
+
+	// dialect holds the conventions by which cardinals are written.
+	dialect object.Dialect
+
+	// lenient records that common nonstandard forms of integers are accepted with warnings.
+	lenient bool
+
+	// warnings holds the warnings recorded in lenient mode.
+	warnings []Warning
+
+	// nonstandard describes the nonstandard forms tolerated in the integer literal being parsed.
+	nonstandard []string
+
+	// incorporated records that the file being parsed is incorporated by another,
+	// and so need not contain a Resolved clause or use its declarations.
+	incorporated bool
+
+	// idents contains the identifiers declared in the innermost scope and records whether each has been used.
 	idents map[string]usage
 
+	// outer holds the scopes enclosing the innermost scope, outermost first.
+	outer []scope
+
+	// risen records that the innermost Committee of the Whole has risen.
+	risen bool
+
+	// cond records that the consequence of an if statement or the fallback of a RecoverStmt is being parsed.
+	cond bool
+
+	// funcs contains the names of the functions that may be called.
+	funcs map[string]bool
+
+	// declPos records the position at which each identifier in the innermost scope was declared.
+	declPos map[string]token.Pos
+
+	// assumed holds the assume statements of each identifier whose assumed values have not yet been read
+	// and may yet be: the most recent unconditional one and any conditional ones that follow it.
+	assumed map[string][]*ast.AssumeStmt
+
+	// unread holds the assume statements whose assumed values are never read.
+	unread []*ast.AssumeStmt
+
+	// positions records the position of the first token of each parsed node.
+	positions map[ast.Node]token.Pos
+
+	// ends records the position following the last token of each parsed expression.
+	ends map[ast.Node]token.Pos
+
+	// clauses records the clauses of the resolution in order.
+	clauses []Clause
+
+	// clauseCount counts the clauses of each kind.
+	clauseCount map[token.Type]int
+
 	// cur holds the current token to be parsed.
 	cur token.Token
 
 	// peek holds the next token after cur.
 	peek token.Token
+
+	// curPos and peekPos hold the positions of cur and peek,
+	// and curEnd and peekEnd the positions following them.
+	curPos, peekPos token.Pos
+	curEnd, peekEnd token.Pos
+
+	// ahead holds the tokens following peek that have been read from l by lookahead.
+	ahead []positioned
+}
+
+// A positioned holds a token, its position, and the position following it.
+type positioned struct {
+	tok      token.Token
+	pos, end token.Pos
+}
+
+// A scope holds the identifiers declared in the resolution or a Committee of the Whole.
+type scope struct {
+	idents  map[string]usage
+	declPos map[string]token.Pos
+}
+
+// NewFile returns a pointer to a Parser that parses tokens from l, which reads the file filename.
+// Resolutions incorporated by reference are found relative to the directory containing filename.
+func NewFile(filename string, l *lexer.Lexer) *Parser {
+	p := New(l)
+	p.filename = filepath.Clean(filename)
+	return p
 }
 
 // New returns a pointer to a Parser that parses tokens from l.
+// Resolutions incorporated by reference are found relative to the current directory.
 func New(l *lexer.Lexer) *Parser {
 	p := &Parser{
-		l:      l,
-		idents: make(map[string]usage),
+		l:           l,
+		idents:      make(map[string]usage),
+		funcs:       make(map[string]bool),
+		declPos:     make(map[string]token.Pos),
+		assumed:     make(map[string][]*ast.AssumeStmt),
+		positions:   make(map[ast.Node]token.Pos),
+		ends:        make(map[ast.Node]token.Pos),
+		clauseCount: make(map[token.Type]int),
 	}
 	p.next()
 	p.next()
@@ -81,13 +292,189 @@ func New(l *lexer.Lexer) *Parser {
 
 // next consumes the next token from p.l.
 func (p *Parser) next() {
-	p.cur = p.peek
-	var err error
-	if p.peek, err = p.l.Next(); err != nil {
-		p.error(err)
+	p.cur, p.curPos, p.curEnd = p.peek, p.peekPos, p.peekEnd
+	if len(p.ahead) > 0 {
+		p.peek, p.peekPos, p.peekEnd = p.ahead[0].tok, p.ahead[0].pos, p.ahead[0].end
+		p.ahead = p.ahead[1:]
+		return
 	}
+	p.peek, p.peekPos, p.peekEnd = p.read()
 }
 
+// read returns the next token from p.l, its position, and the position following it, recording any error.
+func (p *Parser) read() (token.Token, token.Pos, token.Pos) {
+	t, err := p.l.Next()
+	pos := p.l.Pos()
+	if err != nil {
+		p.errorAt(pos, err)
+	}
+	return t, pos, p.l.End()
+}
+
+// lookahead returns the token n tokens after p.peek.
+func (p *Parser) lookahead(n int) token.Token {
+	for len(p.ahead) < n {
+		t, pos, end := p.read()
+		p.ahead = append(p.ahead, positioned{t, pos, end})
+	}
+	return p.ahead[n-1].tok
+}
+
+// tokenAt returns p.cur if n is 0, p.peek if n is 1, and otherwise the token n-1 tokens after p.peek.
+func (p *Parser) tokenAt(n int) token.Token {
+	switch n {
+	case 0:
+		return p.cur
+	case 1:
+		return p.peek
+	default:
+		return p.lookahead(n - 1)
+	}
+}
+
+// phraseAt reports whether the tokens beginning with the one n tokens after p.cur are the words of phrase,
+// which are separated by single spaces and compared without regard to case.
+// A string literal never matches a word.
+func (p *Parser) phraseAt(n int, phrase string) bool {
+	for i, w := range strings.Split(phrase, " ") {
+		if t := p.tokenAt(n + i); t.Typ == token.STRING || !strings.EqualFold(t.Lit, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// joinWords joins the words beginning with p.cur that form an identifier, as described by token.IsIdentifier,
+// into a single identifier, such as "Capital Improvement Fund",
+// which p.cur holds at the position of its first word.
+func (p *Parser) joinWords() {
+	words, pos := p.cur.Lit, p.curPos
+	for token.IsIdentifier(words + " " + p.peek.Lit) {
+		p.next()
+		words += " " + p.cur.Lit
+	}
+	p.cur, p.curPos = token.Token{Typ: token.IDENT, Lit: words}, pos
+}
+
+// joinIdent joins the capitalized words beginning with p.cur into the longest name of a variable or function
+// that they form, which p.cur holds at the position of its first word.
+// If they form no declared name, p.cur holds its first word alone,
+// without its possessive 's if only the word without it is a declared name, as in "Fund's balance".
+func (p *Parser) joinIdent() {
+	name, n := p.cur.Lit, 0
+	words := p.cur.Lit
+	for i := 0; ; i++ {
+		t := p.peek
+		if i > 0 {
+			t = p.lookahead(i)
+		}
+		if !token.IsIdentifier(words + " " + t.Lit) {
+			break
+		}
+		words += " " + t.Lit
+		if p.isDeclared(words) {
+			name, n = words, i+1
+		}
+	}
+	if n == 0 && !p.isDeclared(name) {
+		if base := strings.TrimSuffix(name, "'s"); p.isDeclared(base) {
+			name = base
+			// The name ends before the possessive, whose apostrophe may have been typographic.
+			suffix := len("'s") + p.curEnd.Offset - p.curPos.Offset - len(p.cur.Lit)
+			p.curEnd = token.Pos{Offset: p.curEnd.Offset - suffix, Line: p.curEnd.Line, Col: p.curEnd.Col - len("'s")}
+		}
+	}
+	pos := p.curPos
+	for ; n > 0; n-- {
+		p.next()
+	}
+	p.cur, p.curPos = token.Token{Typ: token.IDENT, Lit: name}, pos
+}
+
+// isDeclared reports whether name is the name of a variable in scope or of a function.
+func (p *Parser) isDeclared(name string) bool { return p.scopeOf(name) != nil || p.funcs[name] }
+
+// skipTo advances p until the Type of p.cur is one of typs.
+// If EOF is reached first, it records errIncomplete and returns false.
+func (p *Parser) skipTo(typs ...token.Type) bool {
+	for {
+		for _, typ := range typs {
+			if p.curIs(typ) {
+				return true
+			}
+		}
+		if p.curIs(token.EOF) {
+			p.error(errIncomplete)
+			return false
+		}
+		p.next()
+	}
+}
+
+// skipComments advances p past any commentary, stopping at a call such as "the finding of the Audit",
+// at an operator phrase such as "absolute value of",
+// or, in lenient mode, at the article of an integer such as "a dozen".
+func (p *Parser) skipComments() {
+	for p.curIs(token.COMMENT) && !p.atCall() && !p.atArticle() && p.prefixOperator() == nil {
+		p.next()
+	}
+}
+
+// skipClauseComments advances p past any commentary, stopping at the last token of a clause.
+func (p *Parser) skipClauseComments() {
+	for p.curIs(token.COMMENT) && !p.peekIs(token.WHEREAS) && !p.peekIs(token.RESOLVED) && !p.peekIs(token.EOF) {
+		p.next()
+	}
+}
+
+// skipToExpr advances p until p.cur can begin an expression.
+// If EOF is reached first, it records errIncomplete and returns false.
+func (p *Parser) skipToExpr() bool {
+	for !isExprToken(p.cur) && !p.atCall() && !p.atArticle() && p.prefixOperator() == nil {
+		if p.curIs(token.EOF) {
+			p.error(errIncomplete)
+			return false
+		}
+		p.next()
+	}
+	return true
+}
+
+// record records pos as the position of n.
+func (p *Parser) record(n ast.Node, pos token.Pos) {
+	if n != nil {
+		p.positions[n] = pos
+	}
+}
+
+// Pos returns the position of the first token of n,
+// or the zero Pos if n was not produced by p.
+func (p *Parser) Pos(n ast.Node) token.Pos { return p.positions[n] }
+
+// recordEnd records the position following p.cur as the end of n.
+func (p *Parser) recordEnd(n ast.Node) {
+	if n != nil {
+		p.ends[n] = p.curEnd
+	}
+}
+
+// End returns the position following the last token of n, an expression or identifier,
+// or the zero Pos if n is not one produced by p.
+// The end of a multi-word identifier is that of its last word, and an identifier referred to
+// in a string literal begins and ends with the literal.
+func (p *Parser) End(n ast.Node) token.Pos { return p.ends[n] }
+
+// Clauses returns the Whereas and Resolved clauses parsed by p, in order,
+// including those that contain no statement.
+func (p *Parser) Clauses() []Clause { return p.clauses }
+
+// Errors returns the errors recorded by p, in the order in which they were recorded.
+// Each is an *Error.
+func (p *Parser) Errors() ErrorList { return p.errors }
+
+// Warnings returns the warnings recorded by p in lenient mode, in the order in which they were recorded.
+func (p *Parser) Warnings() []Warning { return p.warnings }
+
 // curIs reports whether the Type of p.cur is typ.
 func (p *Parser) curIs(typ token.Type) bool { return p.cur.Typ == typ }
 
@@ -105,16 +492,29 @@ func (p *Parser) precedence(t token.Token) precedence {
 		return PREFIX
 	case token.LESS:
 		return INFIX
+	case token.RAISED:
+		return EXPONENT
 	default:
 		return LOWEST
 	}
 }
 
-func (p *Parser) curPrec() precedence  { return p.precedence(p.cur) }
-func (p *Parser) peekPrec() precedence { return p.precedence(p.peek) }
+func (p *Parser) curPrec() precedence { return p.precedence(p.cur) }
 
-// error adds err to p's ErrorList.
-func (p *Parser) error(err error) { p.errors = append(p.errors, err) }
+func (p *Parser) peekPrec() precedence {
+	if p.atRaised(1) {
+		return EXPONENT
+	}
+	return p.precedence(p.peek)
+}
+
+// error adds err to p's ErrorList at the position of the current token.
+func (p *Parser) error(err error) { p.errorAt(p.curPos, err) }
+
+// errorAt adds err to p's ErrorList at pos.
+func (p *Parser) errorAt(pos token.Pos, err error) {
+	p.errors = append(p.errors, &Error{Pos: pos, Err: err})
+}
 
 var (
 	// Resolution parsing failure errors
@@ -123,6 +523,17 @@ var (
 	errLateWhereas   = errors.New("Whereas clause after Resolved clause")
 	errNoResolved    = errors.New("no Resolved clause")
 	errNoWhereas     = errors.New("no Whereas clause")
+
+	// Statement parsing failure errors
+	errIncomplete   = errors.New("incomplete statement")
+	errResolvedDecl = errors.New("declaration in Resolved clause outside a Committee of the Whole")
+	errConditional  = errors.New("declaration or Committee of the Whole in conditional statement")
+	errNoCommittee  = errors.New("no Committee of the Whole to rise")
+	errNoRise       = errors.New("Committee of the Whole did not rise")
+	errNoPath       = errors.New("no resolution named for incorporation")
+	errNoOpen       = errors.New("incorporation by reference is not enabled")
+	errNoForegoing  = errors.New("no foregoing statement to recover")
+	errNoRecorded   = errors.New("no name recorded for failure")
 )
 
 // redeclaredError indicates the redeclaration of an identifier.
@@ -137,19 +548,150 @@ type undeclaredError struct{ ident string }
 // undeclaredError implements the error interface.
 func (err undeclaredError) Error() string { return fmt.Sprintf("%s undeclared", err.ident) }
 
+// notFunctionError indicates the attempted call of an identifier that does not name a function.
+type notFunctionError struct{ ident string }
+
+// notFunctionError implements the error interface.
+func (err notFunctionError) Error() string { return fmt.Sprintf("%s is not a function", err.ident) }
+
+// incorporationError indicates an error in incorporating the resolution in the file path.
+type incorporationError struct {
+	path string
+	err  error
+}
+
+// incorporationError implements the error interface.
+func (err incorporationError) Error() string { return fmt.Sprintf("%s: %v", err.path, err.err) }
+
+// Unwrap returns the underlying error.
+func (err incorporationError) Unwrap() error { return err.err }
+
+// cycleError indicates a resolution that incorporates itself, directly or indirectly.
+type cycleError struct{ files []string }
+
+// cycleError implements the error interface.
+func (err cycleError) Error() string {
+	return fmt.Sprintf("incorporation cycle: %s", strings.Join(err.files, " incorporates "))
+}
+
+// unrecognizedError indicates a token that cannot begin the expected construct, such as an expression.
+type unrecognizedError struct{ what, lit string }
+
+// unrecognizedError implements the error interface.
+func (err unrecognizedError) Error() string {
+	return fmt.Sprintf("unrecognized %s %v", err.what, err.lit)
+}
+
 // unusedError indicates an unused identifier declaration.
 type unusedError struct{ ident string }
 
 // unusedError implements the error interface.
 func (err unusedError) Error() string { return fmt.Sprintf("%s declared but not used", err.ident) }
 
+// Declare declares ident as if by a declaration preceding the resolution,
+// so that it may be used without a DeclStmt. It is not an error for ident to go unused.
+func (p *Parser) Declare(ident string) { p.idents[ident] = used }
+
+// SetDialect sets the conventions by which the cardinals of integer literals are written,
+// including those of the resolutions that p incorporates by reference. The default is object.American.
+func (p *Parser) SetDialect(d object.Dialect) { p.dialect = d }
+
+// SetReadFile sets the function with which p reads the file of each resolution incorporated by reference,
+// such as ioutil.ReadFile. Each file is read whole before it is lexed.
+// If readFile is nil, as it is by default, incorporation by reference is an error.
+func (p *Parser) SetReadFile(readFile func(name string) ([]byte, error)) { p.readFile = readFile }
+
+// SetLenient sets whether p accepts common nonstandard forms of integers, such as "twenty one" and "a dozen",
+// and records a Warning with a suggested canonical rewrite for each instead of an error. The default is strict.
+func (p *Parser) SetLenient(lenient bool) { p.lenient = lenient }
+
+// DeclareFunc declares ident as the name of a function that may be called in a finding expression.
+func (p *Parser) DeclareFunc(ident string) { p.funcs[ident] = true }
+
 // markUsed records that ident has been used.
 // If ident was not declared, it records an undeclaredError instead.
 func (p *Parser) markUsed(ident string) {
-	if p.idents[ident] == undeclared {
+	if idents := p.scopeOf(ident); idents == nil {
 		p.error(undeclaredError{ident})
 	} else {
-		p.idents[ident] = used
+		idents[ident] = used
+		delete(p.assumed, ident)
+	}
+}
+
+// assume records that s assumes a value for its identifier.
+// Unless s is conditional, the values assumed by the previous assume statements of the identifier
+// that have not been read are never read; a conditional s may not replace them.
+func (p *Parser) assume(s *ast.AssumeStmt) {
+	id := s.Name.Value
+	if !p.cond {
+		p.unread = append(p.unread, p.assumed[id]...)
+		p.assumed[id] = nil
+	}
+	p.assumed[id] = append(p.assumed[id], s)
+}
+
+// forgetAssumed records that the values assumed for the identifiers of the innermost scope,
+// or all identifiers if all is true, that have not been read are never read.
+func (p *Parser) forgetAssumed(all bool) {
+	for id, ss := range p.assumed {
+		if all || p.idents[id] != undeclared {
+			p.unread = append(p.unread, ss...)
+			delete(p.assumed, id)
+		}
+	}
+}
+
+// Unread returns the assume statements whose assumed values are never read
+// before they are replaced or the resolution ends, in order of position.
+func (p *Parser) Unread() []*ast.AssumeStmt {
+	unread := append([]*ast.AssumeStmt(nil), p.unread...)
+	sort.Slice(unread, func(i, j int) bool { return p.Pos(unread[i]).Offset < p.Pos(unread[j]).Offset })
+	return unread
+}
+
+// scopeOf returns the identifiers of the innermost scope in which ident is declared,
+// or nil if ident is undeclared.
+func (p *Parser) scopeOf(ident string) map[string]usage {
+	if p.idents[ident] != undeclared {
+		return p.idents
+	}
+	for i := len(p.outer) - 1; i >= 0; i-- {
+		if p.outer[i].idents[ident] != undeclared {
+			return p.outer[i].idents
+		}
+	}
+	return nil
+}
+
+// openScope begins a new innermost scope.
+func (p *Parser) openScope() {
+	p.outer = append(p.outer, scope{p.idents, p.declPos})
+	p.idents = make(map[string]usage)
+	p.declPos = make(map[string]token.Pos)
+}
+
+// closeScope reports the unused identifiers of the innermost scope and ends it.
+func (p *Parser) closeScope() {
+	p.reportUnused()
+	p.forgetAssumed(false)
+	s := p.outer[len(p.outer)-1]
+	p.outer = p.outer[:len(p.outer)-1]
+	p.idents, p.declPos = s.idents, s.declPos
+}
+
+// reportUnused records an unusedError for each unused identifier declared in the innermost scope,
+// in order of declaration.
+func (p *Parser) reportUnused() {
+	var unused []string
+	for id := range p.idents {
+		if p.idents[id] != used {
+			unused = append(unused, id)
+		}
+	}
+	sort.Slice(unused, func(i, j int) bool { return p.declPos[unused[i]].Offset < p.declPos[unused[j]].Offset })
+	for _, id := range unused {
+		p.errorAt(p.declPos[id], unusedError{id})
 	}
 }
 
@@ -168,6 +710,10 @@ func (p *Parser) ParseResolution() (*ast.Resolution, error) {
 	var haveWhereas, haveResolved bool
 
 	for !p.curIs(token.EOF) {
+		var clause int
+		if p.curIs(token.WHEREAS) || p.curIs(token.RESOLVED) {
+			clause = p.beginClause()
+		}
 		switch p.cur.Typ {
 		case token.WHEREAS:
 			if haveResolved {
@@ -176,6 +722,7 @@ func (p *Parser) ParseResolution() (*ast.Resolution, error) {
 			}
 			haveWhereas = true
 			if stmt := p.parseWhereasStmt(); stmt != nil {
+				p.clauses[clause].Stmt = stmt
 				res.WhereasStmts = append(res.WhereasStmts, stmt)
 			}
 		case token.RESOLVED:
@@ -192,95 +739,380 @@ func (p *Parser) ParseResolution() (*ast.Resolution, error) {
 			}
 			haveResolved = true
 			if stmt := p.parseResolvedStmt(); stmt != nil {
-				res.ResolvedStmts = append(res.ResolvedStmts, stmt)
+				res.ResolvedStmts = p.appendResolved(res.ResolvedStmts, stmt, clause)
 			}
 		}
 		p.next()
 	}
+	p.endClause()
+	if p.incorporated {
+		return res, p.errors.Err()
+	}
 	if !haveResolved {
 		p.error(errNoResolved)
 		return nil, p.errors.Err()
 	}
-	for id := range p.idents {
-		if p.idents[id] != used {
-			p.error(unusedError{id})
+	p.reportUnused()
+	p.forgetAssumed(true)
+
+	return res, p.errors.Err()
+}
+
+// beginClause records the beginning of a clause at p.cur and returns its index in p.clauses.
+func (p *Parser) beginClause() int {
+	p.endClause()
+	p.clauseCount[p.cur.Typ]++
+	p.clauses = append(p.clauses, Clause{Token: p.cur, N: p.clauseCount[p.cur.Typ], Pos: p.curPos})
+	return len(p.clauses) - 1
+}
+
+// appendResolved appends stmt, parsed from the clause at index clause in p.clauses, to stmts.
+// If stmt is a RecoverStmt, it instead takes the place of the foregoing statement, which it recovers.
+// The clause is recorded as containing the statement that is carried out when the clause is reached:
+// stmt, or the fallback of a RecoverStmt. If clause is negative, the clause is not recorded.
+func (p *Parser) appendResolved(stmts []ast.ResolvedStmt, stmt ast.ResolvedStmt, clause int) []ast.ResolvedStmt {
+	r, ok := stmt.(*ast.RecoverStmt)
+	if !ok {
+		if clause >= 0 {
+			p.clauses[clause].Stmt = stmt
 		}
+		return append(stmts, stmt)
+	}
+	if len(stmts) == 0 {
+		p.errorAt(p.Pos(r), errNoForegoing)
+		return stmts
+	}
+	r.Stmt = stmts[len(stmts)-1]
+	stmts[len(stmts)-1] = r
+	if clause >= 0 && r.Fallback != nil {
+		p.clauses[clause].Stmt = r.Fallback
+	}
+	return stmts
+}
+
+// endClause records the position of p.cur as the end of the most recent clause, if it has not already ended.
+func (p *Parser) endClause() {
+	if n := len(p.clauses); n > 0 && !p.clauses[n-1].End.IsValid() {
+		p.clauses[n-1].End = p.curPos
 	}
+}
 
-	return res, p.errors.Err()
+// ParseExpr parses a single expression, such as one entered interactively.
+// If parsing fails, it returns an error explaining why.
+func (p *Parser) ParseExpr() (ast.Expr, error) {
+	if !p.skipToExpr() {
+		return nil, p.errors.Err()
+	}
+	expr := p.parseExpr(LOWEST)
+	if err := p.errors.Err(); err != nil {
+		return nil, err
+	}
+	return expr, nil
 }
 
 func (p *Parser) parseWhereasStmt() ast.WhereasStmt {
+	// path holds the most recent string literal, which names the resolution to incorporate, if any.
+	var (
+		path    token.Token
+		pathPos token.Pos
+	)
 	for ; !p.peekIs(token.WHEREAS) && !p.peekIs(token.RESOLVED) && !p.peekIs(token.EOF); p.next() {
-		if !p.curIs(token.HEREINAFTER) {
-			continue
-		}
 		switch p.cur.Typ {
+		case token.STRING:
+			path, pathPos = p.cur, p.curPos
 		case token.HEREINAFTER:
-			return p.parseDeclStmt()
+			if s := p.parseDeclStmt(); s != nil {
+				return s
+			}
+			return nil
+		case token.COMMENT, token.IDENT:
+			if !p.phraseAt(0, "incorporated by reference") {
+				continue
+			}
+			if path.Typ != token.STRING {
+				p.error(errNoPath)
+				return nil
+			}
+			if s := p.parseIncorporationStmt(path.Lit, pathPos); s != nil {
+				return s
+			}
+			return nil
 		}
 	}
 	return nil
 }
 
+// parseIncorporationStmt parses and incorporates the resolution in the file path, which appears at pos,
+// from the phrase "incorporated by reference".
+// The declarations of the incorporated resolution are declared in p and are not reported if unused.
+func (p *Parser) parseIncorporationStmt(path string, pos token.Pos) *ast.IncorporationStmt {
+	s := &ast.IncorporationStmt{Token: token.Token{Typ: token.INCORPORATED, Lit: p.cur.Lit}, Path: path}
+	p.record(s, pos)
+	p.next()
+	p.next()
+	if p.readFile == nil {
+		p.errorAt(pos, errNoOpen)
+		return nil
+	}
+	file := path
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(p.filename), file)
+	}
+	incorporating := append(append([]string(nil), p.incorporating...), p.filename)
+	for i, f := range incorporating {
+		if f == file {
+			p.errorAt(pos, cycleError{append(incorporating[i:], file)})
+			return nil
+		}
+	}
+	b, err := p.readFile(file)
+	if err != nil {
+		p.errorAt(pos, incorporationError{path, err})
+		return nil
+	}
+	child := NewFile(file, lexer.New(string(b)))
+	child.incorporating = incorporating
+	child.readFile = p.readFile
+	child.dialect = p.dialect
+	child.lenient = p.lenient
+	child.incorporated = true
+	res, err := child.ParseResolution()
+	if err != nil {
+		p.errorAt(pos, incorporationError{path, err})
+		return nil
+	}
+	for _, w := range child.warnings {
+		p.warnings = append(p.warnings, Warning{Pos: pos, Message: fmt.Sprintf("%s:%v: %v", path, w.Pos, w.Message), Suggestion: w.Suggestion})
+	}
+	names := make([]string, 0, len(child.idents))
+	for id := range child.idents {
+		names = append(names, id)
+	}
+	sort.Strings(names)
+	for _, id := range names {
+		if p.idents[id] != undeclared {
+			p.errorAt(pos, redeclaredError{id})
+			continue
+		}
+		p.idents[id] = used
+		p.declPos[id] = pos
+	}
+	s.Stmts = res.WhereasStmts
+	return s
+}
+
 func (p *Parser) parseDeclStmt() *ast.DeclStmt {
 	s := &ast.DeclStmt{Token: p.cur}
+	p.record(s, p.curPos)
 	p.next()
-	for !p.curIs(token.IDENT) {
-		p.next()
+	if !p.skipTo(token.IDENT) {
+		return nil
 	}
+	p.joinWords()
 	s.Name = p.parseIdentifier()
 	if id := s.Name.Value; p.idents[id] != undeclared {
 		p.error(redeclaredError{id})
 	} else {
 		p.idents[id] = declared
+		p.declPos[id] = p.curPos
 	}
 	p.next()
-	for !isExprToken(p.cur) {
-		p.next()
-	}
-	if p.curIs(token.IDENT) {
-		p.markUsed(p.cur.Lit)
+	if !p.skipToExpr() {
+		return nil
 	}
 	s.Value = p.parseExpr(LOWEST)
 	return s
 }
 
 func (p *Parser) parseResolvedStmt() ast.ResolvedStmt {
+	// officer is the officer most recently named in the clause.
+	var officer string
 	for ; !p.peekIs(token.WHEREAS) && !p.peekIs(token.RESOLVED) && !p.peekIs(token.EOF); p.next() {
-		if !p.curIs(token.IDENT) && !p.curIs(token.IF) && !p.curIs(token.PUBLISH) {
-			continue
-		}
 		switch p.cur.Typ {
+		case token.COMMENT:
+			if p.phraseAt(0, "should the foregoing fail") {
+				if s := p.parseRecoverStmt(); s != nil {
+					return s
+				}
+				return nil
+			}
+			if !p.phraseAt(0, "resolve itself into a Committee of the Whole") {
+				continue
+			}
+			if p.cond {
+				p.error(errConditional)
+				return nil
+			}
+			// Advance to "Committee", and then to "Whole"
+			for i := 0; i < 4; i++ {
+				p.next()
+			}
+			tok, pos := token.Token{Typ: token.COMMITTEE, Lit: p.cur.Lit}, p.curPos
+			for i := 0; i < 3; i++ {
+				p.next()
+			}
+			return p.parseCommitteeStmt(tok, pos)
 		case token.IDENT:
+			if p.phraseAt(0, "Committee shall rise") {
+				p.next()
+				p.next()
+				switch {
+				case p.cond:
+					p.error(errConditional)
+				case len(p.outer) == 0:
+					p.error(errNoCommittee)
+				default:
+					p.risen = true
+				}
+				return nil
+			}
 			// Assignment if identifier is followed by token.ASSUME
+			p.joinIdent()
 			if !p.peekIs(token.ASSUME) {
-				continue
+				if !ast.IsOfficer(p.cur.Lit) {
+					continue
+				}
+				officer = p.cur.Lit
+				// "the Treasurer shall report" or "the Clerk shall enter into the record"
+				var verb token.Type
+				switch {
+				case p.phraseAt(1, "shall report"):
+					verb = token.REPORT
+				case p.phraseAt(1, "shall enter into the record"):
+					verb = token.ENTER
+				default:
+					continue
+				}
+				p.next()
+				p.next()
+				p.cur = token.Token{Typ: verb, Lit: p.cur.Lit}
+				if s := p.parsePublishStmt(officer); s != nil {
+					return s
+				}
+				return nil
 			}
 			id := p.parseIdentifier()
-			if p.idents[id.Value] == undeclared {
+			if p.scopeOf(id.Value) == nil {
 				p.error(undeclaredError{id.Value})
 				return nil
 			}
 			p.next()
-			return p.parseAssumeStmt(id)
+			if s := p.parseAssumeStmt(id); s != nil {
+				p.assume(s)
+				return s
+			}
+			return nil
 		case token.IF:
-			return p.parseIfStmt()
+			if s := p.parseIfStmt(); s != nil {
+				return s
+			}
+			return nil
 		case token.PUBLISH:
-			return p.parsePublishStmt()
+			if s := p.parsePublishStmt(officer); s != nil {
+				return s
+			}
+			return nil
+		case token.AFFIRMS:
+			if s := p.parseAffirmStmt(); s != nil {
+				return s
+			}
+			return nil
+		case token.HEREINAFTER:
+			switch {
+			case p.cond:
+				p.error(errConditional)
+				return nil
+			case len(p.outer) == 0:
+				p.error(errResolvedDecl)
+				return nil
+			}
+			if s := p.parseDeclStmt(); s != nil {
+				return s
+			}
+			return nil
 		}
 	}
 	return nil
 }
 
+// parseRecoverStmt parses a clause such as "should the foregoing fail, the error being recorded as Problem,
+// the Secretary shall publish Problem", beginning with the phrase "should the foregoing fail".
+// The foregoing statement is supplied by appendResolved.
+func (p *Parser) parseRecoverStmt() *ast.RecoverStmt {
+	// Advance to "fail"
+	for i := 0; i < 3; i++ {
+		p.next()
+	}
+	s := &ast.RecoverStmt{Token: token.Token{Typ: token.FAIL, Lit: p.cur.Lit}}
+	p.record(s, p.curPos)
+	if p.cond {
+		p.error(errNoForegoing)
+		return nil
+	}
+	p.next()
+	for p.curIs(token.COMMENT) && !p.phraseAt(0, "recorded") && !p.peekIs(token.WHEREAS) && !p.peekIs(token.RESOLVED) && !p.peekIs(token.EOF) {
+		p.next()
+	}
+	if p.phraseAt(0, "recorded") {
+		p.next()
+		p.skipClauseComments()
+		if !p.curIs(token.IDENT) {
+			p.error(errNoRecorded)
+			return nil
+		}
+		p.joinWords()
+		s.Name = p.parseIdentifier()
+		p.openScope()
+		defer p.closeScope()
+		p.idents[s.Name.Value] = declared
+		p.declPos[s.Name.Value] = p.curPos
+		p.next()
+	}
+	p.cond = true
+	s.Fallback = p.parseResolvedStmt()
+	p.cond = false
+	return s
+}
+
+// parseCommitteeStmt parses a Committee of the Whole, from the token following the phrase
+// "resolve itself into a Committee of the Whole", whose word "Committee" is tok at pos,
+// through the Resolved clause in which the Committee rises.
+// The remainder of the clause containing the phrase may contain the first statement of the body.
+func (p *Parser) parseCommitteeStmt(tok token.Token, pos token.Pos) *ast.CommitteeStmt {
+	s := &ast.CommitteeStmt{Token: tok}
+	p.record(s, pos)
+	p.openScope()
+	defer p.closeScope()
+	clause := -1
+	stmt := p.parseResolvedStmt()
+	for !p.risen {
+		if stmt != nil {
+			s.Body = p.appendResolved(s.Body, stmt, clause)
+		}
+		for !p.peekIs(token.WHEREAS) && !p.peekIs(token.RESOLVED) && !p.peekIs(token.EOF) {
+			p.next()
+		}
+		if !p.peekIs(token.RESOLVED) {
+			p.errorAt(pos, errNoRise)
+			return s
+		}
+		p.next()
+		clause = p.beginClause()
+		stmt = p.parseResolvedStmt()
+	}
+	p.risen = false
+	return s
+}
+
 func (p *Parser) parseAssumeStmt(ident *ast.Identifier) *ast.AssumeStmt {
 	s := &ast.AssumeStmt{
 		Token: p.cur,
 		Name:  ident,
 	}
+	p.record(s, p.Pos(ident))
 	p.next()
-	for !isExprToken(p.cur) {
-		p.next()
+	if !p.skipToExpr() {
+		return nil
 	}
 	s.Value = p.parseExpr(LOWEST)
 	return s
@@ -288,54 +1120,159 @@ func (p *Parser) parseAssumeStmt(ident *ast.Identifier) *ast.AssumeStmt {
 
 func (p *Parser) parseIfStmt() *ast.IfStmt {
 	s := &ast.IfStmt{Token: p.cur}
+	p.record(s, p.curPos)
+	var ok bool
+	if s.Left, s.Relation, s.Right, ok = p.parseRelation(); !ok {
+		return nil
+	}
 	p.next()
-	for !isExprToken(p.cur) {
-		p.next()
+	p.cond = true
+	s.Consequence = p.parseResolvedStmt()
+	p.cond = false
+	return s
+}
+
+// parseAffirmStmt parses a statement such as "the Assembly affirms that Total equals one hundred (100)".
+func (p *Parser) parseAffirmStmt() *ast.AffirmStmt {
+	s := &ast.AffirmStmt{Token: p.cur}
+	p.record(s, p.curPos)
+	var ok bool
+	if s.Left, s.Relation, s.Right, ok = p.parseRelation(); !ok {
+		return nil
 	}
-	s.Left = p.parseExpr(LOWEST)
+	return s
+}
+
+// parseRelation parses a relation such as "Members exceeds Quorum" or "Members is at least Quorum"
+// following the current token.
+// Any words between the left operand and the relational operator are commentary.
+// It reports whether the relation is complete.
+func (p *Parser) parseRelation() (left ast.Expr, relation token.Token, right ast.Expr, ok bool) {
 	p.next()
-	for !p.curIs(token.EQUALS) && !p.curIs(token.EXCEEDS) {
-		p.next()
+	if !p.skipToExpr() {
+		return nil, token.Token{}, nil, false
 	}
-	s.Relation = p.cur
+	left = p.parseExpr(LOWEST)
 	p.next()
-	for !isExprToken(p.cur) {
+	for {
+		if relation, ok = p.parseRelationalOperator(); ok {
+			break
+		}
+		if p.curIs(token.EOF) {
+			p.error(errIncomplete)
+			return nil, token.Token{}, nil, false
+		}
 		p.next()
 	}
-	s.Right = p.parseExpr(LOWEST)
 	p.next()
-	s.Consequence = p.parseResolvedStmt()
-	return s
+	if !p.skipToExpr() {
+		return nil, token.Token{}, nil, false
+	}
+	right = p.parseExpr(LOWEST)
+	return left, relation, right, true
+}
+
+// relationalPhrases are the phrases that parseRelationalOperator parses as a single relational operator.
+var relationalPhrases = []token.Token{
+	{Typ: token.NOTEQUAL, Lit: "does not equal"},
+	{Typ: token.ATLEAST, Lit: "is at least"},
+	{Typ: token.ATMOST, Lit: "is at most"},
+	{Typ: token.LESSTHAN, Lit: "is less than"},
 }
 
-func (p *Parser) parsePublishStmt() *ast.PublishStmt {
-	s := &ast.PublishStmt{Token: p.cur}
+// parseRelationalOperator parses the relational operator that begins with the current token, if any,
+// advancing p to its last word.
+// A phrase such as "does not equal" is parsed as a single token whose literal is the whole phrase,
+// and it is recognized only as a whole.
+// It reports whether an operator begins with the current token.
+func (p *Parser) parseRelationalOperator() (token.Token, bool) {
+	switch p.cur.Typ {
+	case token.EQUALS, token.EXCEEDS, token.PRECEDES:
+		return p.cur, true
+	}
+	for _, t := range relationalPhrases {
+		if !p.phraseAt(0, t.Lit) {
+			continue
+		}
+		for i := 0; i < len(strings.Fields(t.Lit))-1; i++ {
+			p.next()
+		}
+		return t, true
+	}
+	return token.Token{}, false
+}
+
+// parsePublishStmt parses a statement such as "publish Greeting", "enter into the record Greeting",
+// or "report Total in figures", which the named officer, if any, is directed to carry out.
+func (p *Parser) parsePublishStmt(officer string) *ast.PublishStmt {
+	s := &ast.PublishStmt{Token: p.cur, Officer: officer}
+	p.record(s, p.curPos)
 	p.next()
-	for !isExprToken(p.cur) {
-		p.next()
+	if !p.skipToExpr() {
+		return nil
 	}
 	s.Value = p.parseExpr(LOWEST)
+	s.Format = p.parseFormat()
 	return s
 }
 
+// formatPhrases are the phrases that parseFormat parses as a publish format.
+var formatPhrases = []token.Token{
+	{Typ: token.WORDS, Lit: "in words"},
+	{Typ: token.FIGURES, Lit: "in figures"},
+	{Typ: token.ORDINAL, Lit: "as an ordinal"},
+	{Typ: token.ROMAN, Lit: "in Roman numerals"},
+}
+
+// parseFormat parses the format in which a published value is to be written, such as "in words",
+// "in figures", "as an ordinal", or "in Roman numerals", if one follows the current token,
+// advancing p to its last word.
+// A format is parsed as a single token whose literal is the whole phrase, and it is recognized only as a whole.
+// If none follows, it returns the zero Token.
+func (p *Parser) parseFormat() token.Token {
+	if !p.peekIs(token.COMMENT) {
+		return token.Token{}
+	}
+	for _, t := range formatPhrases {
+		if !p.phraseAt(1, t.Lit) {
+			continue
+		}
+		for i := 0; i < len(strings.Fields(t.Lit)); i++ {
+			p.next()
+		}
+		return t
+	}
+	return token.Token{}
+}
+
 // parseExpr parses an expression.
 func (p *Parser) parseExpr(prec precedence) ast.Expr {
+	pos := p.curPos
 	left := p.parseNullDenotationExpr()
 	if left == nil {
 		return nil
 	}
+	p.record(left, pos)
+	p.recordEnd(left)
 	// Left-associative
 	for prec < p.peekPrec() {
 		switch p.peek.Typ {
 		case token.LESS:
 			p.next()
 			left = p.parseInfixExpr(left)
+		case token.COMMENT:
+			// "raised to the power of", as reported by peekPrec
+			p.next()
+			p.cur = token.Token{Typ: token.RAISED, Lit: p.cur.Lit}
+			left = p.parseInfixExpr(left)
 		case token.SQUARED, token.CUBED:
 			p.next()
 			left = p.parsePostfixExpr(left)
 		default:
 			return left
 		}
+		p.record(left, pos)
+		p.recordEnd(left)
 	}
 	return left
 }
@@ -343,11 +1280,18 @@ func (p *Parser) parseExpr(prec precedence) ast.Expr {
 // parseNullDenotationExpr parses an expression that begins with a null denotation token
 // (representing a literal or prefix operator).
 func (p *Parser) parseNullDenotationExpr() ast.Expr {
-	if p.cur.IsCardinal() {
+	if p.atCall() {
+		return p.parseCallExpr()
+	}
+	if p.cur.IsCardinal() || p.atArticle() {
 		return p.parseIntegerLiteral()
 	}
+	if t := p.prefixOperator(); t != nil {
+		p.cur = token.Token{Typ: t.Typ, Lit: p.cur.Lit}
+	}
 	switch p.cur.Typ {
 	case token.IDENT:
+		p.joinIdent()
 		p.markUsed(p.cur.Lit)
 		return p.parseIdentifier()
 	case token.STRING:
@@ -355,32 +1299,76 @@ func (p *Parser) parseNullDenotationExpr() ast.Expr {
 	case token.NUMERAL:
 		// Let parseIntegerLiteral record the syntax error
 		return p.parseIntegerLiteral()
-	case token.TWICE, token.THRICE:
+	case token.TWICE, token.THRICE, token.ABSOLUTE, token.FACTORIAL:
 		return p.parseUnaryPrefixExpr()
-	case token.SUM, token.PRODUCT, token.QUOTIENT, token.REMAINDER:
+	case token.SUM, token.PRODUCT, token.QUOTIENT, token.REMAINDER, token.GREATER, token.LESSER, token.GREATEST:
 		return p.parseBinaryPrefixExpr()
 	default:
-		p.error(fmt.Errorf("unrecognized expression %v", p.cur.Lit))
+		p.error(unrecognizedError{"expression", p.cur.Lit})
 		return nil
 	}
 }
 
+// parseUnaryPrefixExpr parses a unary prefix expression such as "twice Total" or "the absolute value of Total".
 func (p *Parser) parseUnaryPrefixExpr() ast.Expr {
 	expr := &ast.UnaryPrefixExpr{Token: p.cur}
 	p.next()
+	p.skipComments()
 	expr.Right = p.parseExpr(PREFIX)
 	return expr
 }
 
+// parseBinaryPrefixExpr parses a binary prefix expression such as "sum Total Count"
+// or "the greater of Total and Count".
 func (p *Parser) parseBinaryPrefixExpr() ast.Expr {
 	expr := &ast.BinaryPrefixExpr{Token: p.cur}
 	p.next()
+	p.skipComments()
 	expr.First = p.parseExpr(LOWEST)
 	p.next()
+	if p.phraseAt(0, "and") {
+		p.next()
+		p.skipComments()
+	}
 	expr.Second = p.parseExpr(PREFIX)
 	return expr
 }
 
+// parseCallExpr parses a call of a function such as "the finding of the Audit upon Ledger and Year".
+// Each argument is parsed as the second operand of a binary prefix expression.
+func (p *Parser) parseCallExpr() ast.Expr {
+	expr := &ast.CallExpr{Token: token.Token{Typ: token.FINDING, Lit: p.cur.Lit}}
+	p.next()
+	p.skipComments()
+	if !p.curIs(token.IDENT) {
+		p.error(unrecognizedError{"function", p.cur.Lit})
+		return nil
+	}
+	p.joinIdent()
+	expr.Function = p.parseIdentifier()
+	if !p.funcs[expr.Function.Value] {
+		p.error(notFunctionError{expr.Function.Value})
+		return nil
+	}
+	if !p.phraseAt(1, "upon") {
+		return expr
+	}
+	p.next()
+	for {
+		p.next()
+		p.skipComments()
+		arg := p.parseExpr(PREFIX)
+		if arg == nil {
+			return nil
+		}
+		expr.Args = append(expr.Args, arg)
+		if !p.phraseAt(1, "and") {
+			return expr
+		}
+		p.next()
+	}
+}
+
 // parsePostfixExpr parses a postfix expression: an expression in left denotation context
 // that does not accept a following expression.
 func (p *Parser) parsePostfixExpr(left ast.Expr) ast.Expr {
@@ -399,17 +1387,104 @@ func (p *Parser) parseInfixExpr(left ast.Expr) ast.Expr {
 		Left:  left,
 	}
 	prec := p.curPrec()
+	if p.curIs(token.RAISED) {
+		// Right-associative: "to the power of" precedes the exponent
+		prec--
+	}
 	p.next()
+	p.skipComments()
 	expr.Right = p.parseExpr(prec)
 	return expr
 }
 
 func (p *Parser) parseIdentifier() *ast.Identifier {
-	return &ast.Identifier{Token: p.cur, Value: p.cur.Lit}
+	id := &ast.Identifier{Token: p.cur, Value: p.cur.Lit}
+	p.record(id, p.curPos)
+	p.recordEnd(id)
+	return id
+}
+
+// parseStringLiteral parses a string literal, which may refer to variables by name in square brackets,
+// such as "[Total] members are present". Two consecutive left square brackets denote one,
+// and brackets that do not enclose an identifier denote themselves.
+// If the literal refers to no variables, parseStringLiteral returns an *ast.StringLiteral,
+// and otherwise an *ast.InterpolatedString.
+// The position and end of each reference are recorded as those of the literal.
+func (p *Parser) parseStringLiteral() ast.Expr {
+	s := &ast.InterpolatedString{Token: p.cur}
+	var b strings.Builder
+	text := func() {
+		if b.Len() > 0 {
+			s.Parts = append(s.Parts, &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: b.String()}, Value: b.String()})
+			b.Reset()
+		}
+	}
+	lit := p.cur.Lit
+	for i := 0; i < len(lit); {
+		if strings.HasPrefix(lit[i:], "[[") {
+			b.WriteByte('[')
+			i += 2
+			continue
+		}
+		if lit[i] != '[' {
+			b.WriteByte(lit[i])
+			i++
+			continue
+		}
+		n := strings.IndexByte(lit[i:], ']')
+		name := ""
+		if n >= 0 {
+			name = strings.ReplaceAll(lit[i+1:i+n], "’", "'")
+		}
+		if !token.IsIdentifier(name) {
+			b.WriteByte('[')
+			i++
+			continue
+		}
+		text()
+		p.markUsed(name)
+		id := &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+		p.record(id, p.curPos)
+		p.recordEnd(id)
+		s.Parts = append(s.Parts, id)
+		i += n + 1
+	}
+	if len(s.Parts) == 0 {
+		return &ast.StringLiteral{Token: p.cur, Value: b.String()}
+	}
+	text()
+	return s
+}
+
+// atCall reports whether p.cur begins a call such as "the finding of the Audit".
+func (p *Parser) atCall() bool { return p.phraseAt(0, "finding of") }
+
+// prefixPhrases are the phrases that parseNullDenotationExpr parses as a prefix operator.
+// Each is recognized only as a whole, so that their words are otherwise commentary.
+var prefixPhrases = []token.Token{
+	{Typ: token.ABSOLUTE, Lit: "absolute value of"},
+	{Typ: token.FACTORIAL, Lit: "factorial of"},
+	{Typ: token.GREATER, Lit: "greater of"},
+	{Typ: token.LESSER, Lit: "lesser of"},
+	{Typ: token.GREATEST, Lit: "greatest common divisor of"},
+}
+
+// prefixOperator returns the element of prefixPhrases that begins with p.cur, if any, or else nil.
+func (p *Parser) prefixOperator() *token.Token {
+	if !p.curIs(token.COMMENT) {
+		return nil
+	}
+	for i, t := range prefixPhrases {
+		if p.phraseAt(0, t.Lit) {
+			return &prefixPhrases[i]
+		}
+	}
+	return nil
 }
 
-func (p *Parser) parseStringLiteral() *ast.StringLiteral {
-	return &ast.StringLiteral{Token: p.cur, Value: p.cur.Lit}
+// atRaised reports whether the token n tokens after p.cur begins the phrase "raised to the power of".
+func (p *Parser) atRaised(n int) bool {
+	return p.tokenAt(n).Typ == token.COMMENT && p.phraseAt(n, "raised to the power of")
 }
 
 // isExprToken reports whether t can begin an ast.Expr.
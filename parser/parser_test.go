@@ -1,9 +1,13 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"io/ioutil"
 	"math"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dkmccandless/assembly/ast"
@@ -11,10 +15,10 @@ import (
 	"github.com/dkmccandless/assembly/token"
 )
 
-// lastError returns the last error in p.errors, or nil if no errors were recorded.
+// lastError returns the underlying error of the last error in p.errors, or nil if no errors were recorded.
 func (p *Parser) lastError() error {
 	if len(p.errors) != 0 {
-		return p.errors[len(p.errors)-1]
+		return errors.Unwrap(p.errors[len(p.errors)-1])
 	}
 	return nil
 }
@@ -267,7 +271,7 @@ resolved publish Stock`,
 		ast, err := p.ParseResolution()
 		if err != nil {
 			// Test the actual value of the last error generated
-			err = p.errors[len(p.errors)-1]
+			err = p.lastError()
 		}
 		if !reflect.DeepEqual(ast, test.ast) || err != test.err {
 			t.Errorf("ParseResolution(%v): got %v, %v; want %v, %v", test.input, ast, err, test.ast, test.err)
@@ -318,6 +322,35 @@ func TestParseDeclStmt(t *testing.T) {
 	}
 }
 
+func TestDeclFromIdentifier(t *testing.T) {
+	const input = `A Resolution Concerning the Funds
+
+WHEREAS the Capital Improvement Fund (hereinafter Capital Improvement Fund) is one hundred (100);
+WHEREAS the Reserve (hereinafter Reserve) is the Capital Improvement Fund;
+WHEREAS the Balance (hereinafter Balance) is the Reserve's balance: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Balance.`
+	res, err := New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	for i, want := range []string{"Capital Improvement Fund", "Reserve"} {
+		if id, ok := res.WhereasStmts[i+1].(*ast.DeclStmt).Value.(*ast.Identifier); !ok || id.Value != want {
+			t.Errorf("declaration %v: got value %v, want %v", i+2, res.WhereasStmts[i+1].(*ast.DeclStmt).Value, want)
+		}
+	}
+
+	const undeclared = `A Resolution Concerning the Funds
+
+WHEREAS the Reserve (hereinafter Reserve) is the Surplus: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Reserve.`
+	_, err = New(lexer.New(undeclared)).ParseResolution()
+	if el, ok := err.(ErrorList); !ok || len(el) != 1 || el[0].(*Error).Err != (undeclaredError{"Surplus"}) {
+		t.Errorf("ParseResolution: got %v, want a single Surplus undeclared error", err)
+	}
+}
+
 func TestParseAssumeStmt(t *testing.T) {
 	for _, test := range []struct {
 		input string
@@ -430,6 +463,36 @@ func TestParseIfStmt(t *testing.T) {
 	}
 }
 
+func TestParseAffirmStmt(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		want  *ast.AffirmStmt
+	}{
+		{
+			`affirms that Total equals one hundred (100)`,
+			&ast.AffirmStmt{
+				Token:    token.Token{token.AFFIRMS, "affirms"},
+				Left:     &ast.Identifier{token.Token{token.IDENT, "Total"}, "Total"},
+				Right:    &ast.IntegerLiteral{token.Token{token.INTEGER, "100"}, 100},
+				Relation: token.Token{token.EQUALS, "equals"},
+			},
+		},
+		{
+			`affirms that the Members present exceed the Quorum`,
+			nil,
+		},
+	} {
+		p := New(lexer.New(test.input))
+		p.idents["Total"] = declared
+		p.idents["Members"] = declared
+		p.idents["Quorum"] = declared
+		got := p.parseAffirmStmt()
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseAffirmStmt(%v): got %#v, want %#v", test.input, got, test.want)
+		}
+	}
+}
+
 func TestParsePublishStmt(t *testing.T) {
 	for _, test := range []struct {
 		input string
@@ -465,10 +528,74 @@ func TestParsePublishStmt(t *testing.T) {
 				},
 			},
 		},
+		{
+			"publish Message in figures",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+				Format: token.Token{Typ: token.FIGURES, Lit: "in figures"},
+			},
+		},
+		{
+			"publish Message in words",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+				Format: token.Token{Typ: token.WORDS, Lit: "in words"},
+			},
+		},
+		{
+			"publish Message as an ordinal",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+				Format: token.Token{Typ: token.ORDINAL, Lit: "as an ordinal"},
+			},
+		},
+		{
+			"publish Message in Roman numerals",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+				Format: token.Token{Typ: token.ROMAN, Lit: "in Roman numerals"},
+			},
+		},
+		{
+			"publish, upon finding it prudent, Message",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+			},
+		},
+		{
+			"publish Message in the minutes in words",
+			&ast.PublishStmt{
+				Token: token.Token{Typ: token.PUBLISH, Lit: "publish"},
+				Value: &ast.Identifier{
+					Token: token.Token{Typ: token.IDENT, Lit: "Message"},
+					Value: "Message",
+				},
+			},
+		},
 	} {
 		p := New(lexer.New(test.input))
 		p.idents["Message"] = declared
-		got := p.parsePublishStmt()
+		got := p.parsePublishStmt("")
 		err := p.lastError()
 		if err != nil || !reflect.DeepEqual(got, test.want) {
 			t.Errorf("parsePublishStmt(%v): got %#v, %v, want %#v", test.input, got, err, test.want)
@@ -476,12 +603,228 @@ func TestParsePublishStmt(t *testing.T) {
 	}
 }
 
+// TestFormatWords tests that the words of a publish format are otherwise commentary or identifiers.
+func TestFormatWords(t *testing.T) {
+	const input = `A Resolution Concerning the Roman Road
+
+WHEREAS the Roman Road (hereinafter Roman Road) is five (5) miles, in words and in figures alike;
+WHEREAS the Welcome Words (hereinafter Welcome Words) is "Salve": now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Welcome Words as an ordinary greeting; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Roman Road in figures.`
+	res, err := New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	for i, want := range []struct {
+		name   string
+		format token.Token
+	}{
+		{"Welcome Words", token.Token{}},
+		{"Roman Road", token.Token{Typ: token.FIGURES, Lit: "in figures"}},
+	} {
+		s := res.ResolvedStmts[i].(*ast.PublishStmt)
+		if id, ok := s.Value.(*ast.Identifier); !ok || id.Value != want.name || s.Format != want.format {
+			t.Errorf("publish %v: got %#v in %v, want %v in %v", i+1, s.Value, s.Format, want.name, want.format)
+		}
+	}
+}
+
 var identifierTests = []string{
 	"Greeting",
 	"Quantity",
 	"Answer",
 }
 
+func TestParseRelation(t *testing.T) {
+	for _, test := range []struct {
+		input    string
+		relation token.Token
+		err      error
+	}{
+		{`affirms that Total equals Quorum`, token.Token{token.EQUALS, "equals"}, nil},
+		{`affirms that Total does not equal Quorum`, token.Token{token.NOTEQUAL, "does not equal"}, nil},
+		{`affirms that Total is at least Quorum`, token.Token{token.ATLEAST, "is at least"}, nil},
+		{`affirms that Total is at most Quorum`, token.Token{token.ATMOST, "is at most"}, nil},
+		{`affirms that Total is less than Quorum`, token.Token{token.LESSTHAN, "is less than"}, nil},
+		{`affirms that Total precedes Quorum alphabetically`, token.Token{token.PRECEDES, "precedes"}, nil},
+		{`affirms that Total less one (1) exceeds Quorum`, token.Token{token.EXCEEDS, "exceeds"}, nil},
+		{`affirms that Total, not counting proxies, exceeds Quorum`, token.Token{token.EXCEEDS, "exceeds"}, nil},
+		{`affirms that Total, at least by most accounts, equals Quorum`, token.Token{token.EQUALS, "equals"}, nil},
+		{`affirms that Total does not exceed Quorum`, token.Token{}, errIncomplete},
+		{`affirms that Total is less Quorum`, token.Token{}, errIncomplete},
+	} {
+		p := New(lexer.New(test.input))
+		p.idents["Total"] = declared
+		p.idents["Quorum"] = declared
+		got := p.parseAffirmStmt()
+		if err := p.lastError(); err != test.err {
+			t.Errorf("parseAffirmStmt(%v): got error %v, want %v", test.input, err, test.err)
+		}
+		if test.err != nil {
+			continue
+		}
+		if got == nil || got.Relation != test.relation {
+			t.Errorf("parseAffirmStmt(%v): got %#v, want relation %v", test.input, got, test.relation)
+		}
+	}
+}
+
+func TestOfficers(t *testing.T) {
+	input := `A Resolution Concerning the Accounts
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish "Dues are payable."; and
+BE IT FURTHER RESOLVED that the Clerk shall enter into the record "Dues assessed."; and
+BE IT FURTHER RESOLVED that the Treasurer shall report Total; and
+BE IT FURTHER RESOLVED that the Assembly directs that the Clerk shall report Total; and
+BE IT FURTHER RESOLVED that this Assembly shall publish Total; and
+BE IT FURTHER RESOLVED that if Total exceeds ten (10), the Treasurer shall publish Total.`
+	res, err := New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	for i, want := range []struct{ verb, officer, responsible string }{
+		{"publish", ast.Secretary, ast.Secretary},
+		{"enter", ast.Clerk, ast.Clerk},
+		{"report", ast.Treasurer, ast.Treasurer},
+		{"report", ast.Clerk, ast.Clerk},
+		{"publish", "", ast.Secretary},
+		{"publish", ast.Treasurer, ast.Treasurer},
+	} {
+		stmt := res.ResolvedStmts[i]
+		if s, ok := stmt.(*ast.IfStmt); ok {
+			stmt = s.Consequence
+		}
+		s, ok := stmt.(*ast.PublishStmt)
+		if !ok {
+			t.Errorf("statement %d: got %T, want *ast.PublishStmt", i+1, stmt)
+			continue
+		}
+		if s.Token.Lit != want.verb || s.Officer != want.officer || s.Responsible() != want.responsible {
+			t.Errorf("statement %d: got %v by %q (responsible %v), want %v by %q (responsible %v)",
+				i+1, s.Token.Lit, s.Officer, s.Responsible(), want.verb, want.officer, want.responsible)
+		}
+	}
+
+	// "report" and "enter" are verbs only following an officer and "shall",
+	// and elsewhere are commentary or part of a name.
+	input = `A Resolution Concerning the Annual Report
+
+WHEREAS the Annual Report (hereinafter Report) is two (2): now, therefore,
+
+BE IT RESOLVED that, in light of the annual report, the Secretary shall publish Report; and
+BE IT FURTHER RESOLVED that, lest we enter into debt, the Treasurer shall report Report.`
+	res, err = New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	for i, want := range []struct{ verb, officer string }{
+		{"publish", ast.Secretary},
+		{"report", ast.Treasurer},
+	} {
+		s, ok := res.ResolvedStmts[i].(*ast.PublishStmt)
+		if !ok {
+			t.Errorf("statement %d: got %T, want *ast.PublishStmt", i+1, res.ResolvedStmts[i])
+			continue
+		}
+		if s.Token.Lit != want.verb || s.Officer != want.officer || s.Value.String() != "Report" {
+			t.Errorf("statement %d: got %v %v by %q, want %v Report by %q", i+1, s.Token.Lit, s.Value, s.Officer, want.verb, want.officer)
+		}
+	}
+}
+
+func TestMultiWordIdentifiers(t *testing.T) {
+	input := `A Resolution Concerning the Capital Improvement Fund
+
+WHEREAS the sums set aside for repairs (hereinafter the Capital Improvement Fund) are two thousand (2,000); and
+WHEREAS the balance reported by the Treasurer (hereinafter the Treasurer’s Report) is five hundred (500); and
+WHEREAS the reserve (hereinafter the Capital) is one (1): now, therefore,
+
+BE IT RESOLVED that the Capital Improvement Fund assume the value sum Capital Improvement Fund Treasurer's Report; and
+BE IT FURTHER RESOLVED that the Secretary shall publish "[Capital Improvement Fund] and [Capital] Improvement"; and
+BE IT FURTHER RESOLVED that the Secretary shall publish the Capital’s balance.`
+	p := New(lexer.New(input))
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	var names []string
+	for _, s := range res.WhereasStmts {
+		names = append(names, s.(*ast.DeclStmt).Name.Value)
+	}
+	if want := []string{"Capital Improvement Fund", "Treasurer's Report", "Capital"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("declarations: got %q, want %q", names, want)
+	}
+	s := res.ResolvedStmts[0].(*ast.AssumeStmt)
+	sum := s.Value.(*ast.BinaryPrefixExpr)
+	got := []string{s.Name.Value, sum.First.String(), sum.Second.String()}
+	if want := []string{"Capital Improvement Fund", "Capital Improvement Fund", "Treasurer's Report"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("statement 1: got %q, want %q", got, want)
+	}
+	parts := res.ResolvedStmts[1].(*ast.PublishStmt).Value.(*ast.InterpolatedString).Parts
+	got = nil
+	for _, part := range parts {
+		got = append(got, part.String())
+	}
+	if want := []string{"Capital Improvement Fund", " and ", "Capital", " Improvement"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("statement 2: got %q, want %q", got, want)
+	}
+	// A possessive of a name that is not itself declared refers to the name.
+	if got := res.ResolvedStmts[2].(*ast.PublishStmt).Value.String(); got != "Capital" {
+		t.Errorf("statement 3: got %q, want %q", got, "Capital")
+	}
+
+	// The end of an identifier is that of its last word, excluding any possessive that is not part of the name.
+	for _, test := range []struct {
+		id   ast.Node
+		text string
+	}{
+		{s.Name, "Capital Improvement Fund assume"},
+		{sum.Second, "Treasurer's Report;"},
+		{res.ResolvedStmts[2].(*ast.PublishStmt).Value, "Capital’s balance"},
+	} {
+		start := strings.Index(input, test.text)
+		end := start + len(test.id.String())
+		if got := p.End(test.id); got.Offset != end {
+			t.Errorf("End(%v): got offset %v, want %v", test.id, got.Offset, end)
+		}
+	}
+}
+
+func TestDozenAndScore(t *testing.T) {
+	const input = `A Resolution Concerning the Match
+
+WHEREAS the final score of the match (hereinafter Score) is five (5): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Score`
+	res, err := New(lexer.New(input + ".")).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	if v, ok := res.WhereasStmts[0].(*ast.DeclStmt).Value.(*ast.IntegerLiteral); !ok || v.Value != 5 {
+		t.Errorf("got declared value %v, want 5", res.WhereasStmts[0].(*ast.DeclStmt).Value)
+	}
+	if id, ok := res.ResolvedStmts[0].(*ast.PublishStmt).Value.(*ast.Identifier); !ok || id.Value != "Score" {
+		t.Errorf("got published value %v, want Score", res.ResolvedStmts[0].(*ast.PublishStmt).Value)
+	}
+
+	// "a dozen" is an integer only in lenient mode.
+	const dozen = input + "; and\nBE IT FURTHER RESOLVED that the Secretary shall publish a dozen."
+	if _, err := New(lexer.New(dozen)).ParseResolution(); err == nil {
+		t.Error("strict ParseResolution: got no error")
+	}
+	p := New(lexer.New(dozen))
+	p.SetLenient(true)
+	if res, err = p.ParseResolution(); err != nil {
+		t.Fatalf("lenient ParseResolution: unexpected error: %v", err)
+	}
+	if v, ok := res.ResolvedStmts[1].(*ast.PublishStmt).Value.(*ast.IntegerLiteral); !ok || v.Value != 12 {
+		t.Errorf("got published value %v, want 12", res.ResolvedStmts[1].(*ast.PublishStmt).Value)
+	}
+}
+
 func TestParseIdentifier(t *testing.T) {
 	for _, test := range identifierTests {
 		want := &ast.Identifier{
@@ -516,6 +859,54 @@ func TestParseStringLiteral(t *testing.T) {
 	}
 }
 
+func TestParseInterpolatedString(t *testing.T) {
+	str := func(s string) *ast.StringLiteral {
+		return &ast.StringLiteral{Token: token.Token{Typ: token.STRING, Lit: s}, Value: s}
+	}
+	ident := func(name string) *ast.Identifier {
+		return &ast.Identifier{Token: token.Token{Typ: token.IDENT, Lit: name}, Value: name}
+	}
+	for _, test := range []struct {
+		input string
+		parts []ast.Expr
+		err   error
+	}{
+		{`"[Total] members are present."`, []ast.Expr{ident("Total"), str(" members are present.")}, nil},
+		{`"Of [Total] members, [Present] are present"`, []ast.Expr{str("Of "), ident("Total"), str(" members, "), ident("Present"), str(" are present")}, nil},
+		{`"[Total][Present]"`, []ast.Expr{ident("Total"), ident("Present")}, nil},
+		{`"[Absent] members are absent."`, []ast.Expr{ident("Absent"), str(" members are absent.")}, undeclaredError{"Absent"}},
+		{`"[[Total] is [Total]"`, []ast.Expr{str("[Total] is "), ident("Total")}, nil},
+	} {
+		p := New(lexer.New(test.input))
+		p.idents["Total"] = declared
+		p.idents["Present"] = declared
+		want := &ast.InterpolatedString{Token: p.cur, Parts: test.parts}
+		got := p.parseStringLiteral()
+		if err := p.lastError(); !reflect.DeepEqual(got, want) || err != test.err {
+			t.Errorf("parseStringLiteral(%v): got %v, %v; want %v, %v", test.input, got, err, want, test.err)
+		}
+		if test.err == nil && p.idents["Total"] != used {
+			t.Errorf("parseStringLiteral(%v): Total not used", test.input)
+		}
+	}
+
+	// Brackets that do not enclose an identifier denote themselves.
+	for _, test := range []struct{ input, want string }{
+		{`"[sic]"`, "[sic]"},
+		{`"[Whereas]"`, "[Whereas]"},
+		{`"[Total members]"`, "[Total members]"},
+		{`"[[Total]"`, "[Total]"},
+		{`"[Total"`, "[Total"},
+	} {
+		p := New(lexer.New(test.input))
+		p.idents["Total"] = declared
+		got, ok := p.parseStringLiteral().(*ast.StringLiteral)
+		if !ok || got.Value != test.want || p.lastError() != nil {
+			t.Errorf("parseStringLiteral(%v): got %#v, %v; want %q", test.input, got, p.lastError(), test.want)
+		}
+	}
+}
+
 func TestParseExpr(t *testing.T) {
 	for _, test := range []struct {
 		input string
@@ -770,6 +1161,20 @@ func TestParseUnaryPrefixExpr(t *testing.T) {
 				},
 			},
 		},
+		{
+			"absolute value of negative three (-3)",
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.ABSOLUTE, "absolute"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "-3"}, -3},
+			},
+		},
+		{
+			"factorial of five (5)",
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.FACTORIAL, "factorial"},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "5"}, 5},
+			},
+		},
 	} {
 		p := New(lexer.New(test.input))
 		expr := p.parseExpr(LOWEST)
@@ -817,6 +1222,33 @@ func TestParseBinaryPrefixExpr(t *testing.T) {
 				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "5"}, 5},
 			},
 		},
+		{
+			"greater of twelve (12) and five (5)",
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.GREATER, "greater"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "12"}, 12},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "5"}, 5},
+			},
+		},
+		{
+			"lesser of twelve (12) and five (5)",
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.LESSER, "lesser"},
+				First:  &ast.IntegerLiteral{token.Token{token.INTEGER, "12"}, 12},
+				Second: &ast.IntegerLiteral{token.Token{token.INTEGER, "5"}, 5},
+			},
+		},
+		{
+			"greatest common divisor of twelve (12) and eighteen (18) squared",
+			&ast.BinaryPrefixExpr{
+				Token: token.Token{token.GREATEST, "greatest"},
+				First: &ast.IntegerLiteral{token.Token{token.INTEGER, "12"}, 12},
+				Second: &ast.PostfixExpr{
+					Token: token.Token{token.SQUARED, "squared"},
+					Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "18"}, 18},
+				},
+			},
+		},
 	} {
 		p := New(lexer.New(test.input))
 		expr := p.parseExpr(LOWEST)
@@ -827,6 +1259,115 @@ func TestParseBinaryPrefixExpr(t *testing.T) {
 	}
 }
 
+// TestOperatorWords tests that the words of an operator phrase are otherwise commentary or identifiers.
+func TestOperatorWords(t *testing.T) {
+	p := New(lexer.New("the greatest of Count and Other"))
+	p.idents["Count"] = declared
+	p.idents["Other"] = declared
+	p.skipToExpr()
+	if expr, want := p.parseExpr(LOWEST), (&ast.Identifier{token.Token{token.IDENT, "Count"}, "Count"}); !reflect.DeepEqual(expr, want) || p.lastError() != nil {
+		t.Errorf("parseExpr: got %#v, %v; want %#v", expr, p.lastError(), want)
+	}
+
+	const input = `A Resolution Concerning the Greatest Need
+
+WHEREAS the Absolute Majority (hereinafter Absolute Majority) is five (5), no greater than the membership;
+WHEREAS the Greatest Need (hereinafter Greatest Need) is the Absolute Majority raised at the last meeting;
+WHEREAS the Factorial (hereinafter Factorial) is the lesser sum of Greatest Need and one (1): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the greater of Factorial and two (2) raised to the power of two (2).`
+	res, err := New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	for i, want := range []string{"Absolute Majority", "Greatest Need", "Factorial"} {
+		if name := res.WhereasStmts[i].(*ast.DeclStmt).Name.Value; name != want {
+			t.Errorf("declaration %v: got name %v, want %v", i+1, name, want)
+		}
+	}
+	if v, ok := res.WhereasStmts[0].(*ast.DeclStmt).Value.(*ast.IntegerLiteral); !ok || v.Value != 5 {
+		t.Errorf("declaration 1: got value %#v, want 5", res.WhereasStmts[0].(*ast.DeclStmt).Value)
+	}
+	if v, ok := res.WhereasStmts[1].(*ast.DeclStmt).Value.(*ast.Identifier); !ok || v.Value != "Absolute Majority" {
+		t.Errorf("declaration 2: got value %#v, want Absolute Majority", res.WhereasStmts[1].(*ast.DeclStmt).Value)
+	}
+	if v, ok := res.WhereasStmts[2].(*ast.DeclStmt).Value.(*ast.BinaryPrefixExpr); !ok || v.Token.Typ != token.SUM {
+		t.Errorf("declaration 3: got value %#v, want a sum", res.WhereasStmts[2].(*ast.DeclStmt).Value)
+	}
+	if v, ok := res.ResolvedStmts[0].(*ast.PublishStmt).Value.(*ast.InfixExpr); !ok || v.Token.Typ != token.RAISED {
+		t.Errorf("publish: got value %#v, want an exponentiation", res.ResolvedStmts[0].(*ast.PublishStmt).Value)
+	} else if e, ok := v.Left.(*ast.BinaryPrefixExpr); !ok || e.Token.Typ != token.GREATER {
+		t.Errorf("publish: got base %#v, want the greater of Factorial and two", v.Left)
+	}
+}
+
+func TestParseCallExpr(t *testing.T) {
+	audit := &ast.Identifier{token.Token{token.IDENT, "Audit"}, "Audit"}
+	ledger := &ast.Identifier{token.Token{token.IDENT, "Ledger"}, "Ledger"}
+	for _, test := range []struct {
+		input string
+		expr  ast.Expr
+	}{
+		{
+			"finding of the Audit",
+			&ast.CallExpr{Token: token.Token{token.FINDING, "finding"}, Function: audit},
+		},
+		{
+			"finding of the Audit upon the Ledger",
+			&ast.CallExpr{Token: token.Token{token.FINDING, "finding"}, Function: audit, Args: []ast.Expr{ledger}},
+		},
+		{
+			"finding of the Audit upon Ledger and two (2) less one (1)",
+			&ast.InfixExpr{
+				Token: token.Token{token.LESS, "less"},
+				Left: &ast.CallExpr{
+					Token:    token.Token{token.FINDING, "finding"},
+					Function: audit,
+					Args:     []ast.Expr{ledger, &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2}},
+				},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "1"}, 1},
+			},
+		},
+		{
+			"twice the finding of the Audit",
+			&ast.UnaryPrefixExpr{
+				Token: token.Token{token.TWICE, "twice"},
+				Right: &ast.CallExpr{Token: token.Token{token.FINDING, "finding"}, Function: audit},
+			},
+		},
+		{
+			"greater of Ledger and the finding of the Audit",
+			&ast.BinaryPrefixExpr{
+				Token:  token.Token{token.GREATER, "greater"},
+				First:  ledger,
+				Second: &ast.CallExpr{Token: token.Token{token.FINDING, "finding"}, Function: audit},
+			},
+		},
+	} {
+		p := New(lexer.New(test.input))
+		p.DeclareFunc("Audit")
+		p.idents["Ledger"] = declared
+		expr := p.parseExpr(LOWEST)
+		err := p.lastError()
+		if !reflect.DeepEqual(expr, test.expr) || err != nil {
+			t.Errorf("ParseCallExpr(%v): got %#v, %v; want %#v", test.input, expr, err, test.expr)
+		}
+	}
+	for _, input := range []string{
+		"finding of the Ledger",
+		"finding of the Review",
+		"finding of the Audit upon",
+		"finding of the Audit upon Ledger and",
+	} {
+		p := New(lexer.New(input))
+		p.DeclareFunc("Audit")
+		p.idents["Ledger"] = declared
+		if expr := p.parseExpr(LOWEST); expr != nil || p.lastError() == nil {
+			t.Errorf("ParseCallExpr(%v): got %v, %v; want error", input, expr, p.lastError())
+		}
+	}
+}
+
 func TestParseInfixExpr(t *testing.T) {
 	for _, test := range []struct {
 		input string
@@ -840,6 +1381,35 @@ func TestParseInfixExpr(t *testing.T) {
 				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
 			},
 		},
+		{
+			"two (2) raised to the power of ten (10)",
+			&ast.InfixExpr{
+				Token: token.Token{token.RAISED, "raised"},
+				Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "10"}, 10},
+			},
+		},
+		{
+			// Exponentiation is right-associative and binds more tightly than less.
+			"nine (9) less two (2) raised to the power of three (3) raised to the power of two (2) less one (1)",
+			&ast.InfixExpr{
+				Token: token.Token{token.LESS, "less"},
+				Left: &ast.InfixExpr{
+					Token: token.Token{token.LESS, "less"},
+					Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "9"}, 9},
+					Right: &ast.InfixExpr{
+						Token: token.Token{token.RAISED, "raised"},
+						Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+						Right: &ast.InfixExpr{
+							Token: token.Token{token.RAISED, "raised"},
+							Left:  &ast.IntegerLiteral{token.Token{token.INTEGER, "3"}, 3},
+							Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "2"}, 2},
+						},
+					},
+				},
+				Right: &ast.IntegerLiteral{token.Token{token.INTEGER, "1"}, 1},
+			},
+		},
 	} {
 		p := New(lexer.New(test.input))
 		expr := p.parseExpr(LOWEST)
@@ -849,3 +1419,378 @@ func TestParseInfixExpr(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorPos(t *testing.T) {
+	for _, test := range []struct {
+		input string
+		pos   token.Pos
+		err   error
+	}{
+		{
+			"title\nwhereas\nresolved publish Greeting",
+			token.Pos{Offset: 31, Line: 3, Col: 18},
+			undeclaredError{"Greeting"},
+		},
+		{
+			"title\nwhereas the Greeting (hereinafter Greeting) is \"Hello\"\nresolved",
+			token.Pos{Offset: 40, Line: 2, Col: 35},
+			unusedError{"Greeting"},
+		},
+		{
+			"title\nwhereas the Count (hereinafter Count) is one (1)\nwhereas (hereinafter Count) is two (2)\nresolved publish Count",
+			token.Pos{Offset: 76, Line: 3, Col: 22},
+			redeclaredError{"Count"},
+		},
+		{
+			"title\nwhereas\nresolved publish\n  \"The Chair ruled\n  \"\"Out of order.",
+			token.Pos{Offset: 33, Line: 4, Col: 3},
+			lexer.ErrQuote,
+		},
+	} {
+		p := New(lexer.New(test.input))
+		p.ParseResolution()
+		if len(p.errors) != 1 {
+			t.Fatalf("ParseResolution(%v): got errors %v, want 1 error", test.input, p.errors)
+		}
+		if got := p.errors[0].(*Error); got.Pos != test.pos || got.Err != test.err {
+			t.Errorf("ParseResolution(%v): got %v at %+v, want %v at %+v", test.input, got.Err, got.Pos, test.err, test.pos)
+		}
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		code string
+	}{
+		{undeclaredError{"Greeting"}, "undeclared"},
+		{unrecognizedError{"expression", "of"}, "unrecognized-expression"},
+		{cycleError{[]string{"a.asm", "b.asm", "a.asm"}}, "incorporation-cycle"},
+		{errNoResolved, "no-resolved"},
+		{errDisagree, "cardinal-numeral-disagree"},
+		{errRoman, "invalid-roman-numeral"},
+		{lexer.ErrQuote, "no-closing-quote"},
+		{errors.New("unexpected"), "syntax"},
+	} {
+		if got := (&Error{Err: test.err}).Code(); got != test.code {
+			t.Errorf("Code(%v): got %q, want %q", test.err, got, test.code)
+		}
+	}
+}
+
+func TestIncompleteStmt(t *testing.T) {
+	for _, input := range []string{
+		"title whereas (hereinafter",
+		"title whereas (hereinafter Greeting) is",
+		"title whereas resolved if",
+		"title whereas resolved if Quorum",
+		"title whereas resolved if Quorum exceeds",
+		"title whereas resolved publish",
+		"title whereas resolved Total assume",
+	} {
+		p := New(lexer.New(input))
+		p.idents["Quorum"] = declared
+		p.idents["Total"] = declared
+		if _, err := p.ParseResolution(); err == nil {
+			t.Errorf("ParseResolution(%v): got no error", input)
+		}
+	}
+}
+
+func TestClauses(t *testing.T) {
+	input := `A Resolution Concerning Clauses
+
+WHEREAS the Customary Greeting (hereinafter Greeting) is "Hello, World!";
+WHEREAS no further context is needed: now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the aforesaid Greeting; and
+BE IT FURTHER RESOLVED that this Assembly takes no further action.`
+	p := New(lexer.New(input))
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	clauses := p.Clauses()
+	if len(clauses) != 4 {
+		t.Fatalf("Clauses: got %v clauses, want 4", len(clauses))
+	}
+	for i, want := range []struct {
+		typ  token.Type
+		n    int
+		text string
+		stmt ast.Node
+	}{
+		{token.WHEREAS, 1, `WHEREAS the Customary Greeting (hereinafter Greeting) is "Hello, World!";`, res.WhereasStmts[0]},
+		{token.WHEREAS, 2, "WHEREAS no further context is needed: now, therefore,\n\nBE IT", nil},
+		{token.RESOLVED, 1, "RESOLVED that the Secretary shall publish the aforesaid Greeting; and\nBE IT FURTHER", res.ResolvedStmts[0]},
+		{token.RESOLVED, 2, "RESOLVED that this Assembly takes no further action.", nil},
+	} {
+		c := clauses[i]
+		if c.Token.Typ != want.typ || c.N != want.n || c.Stmt != want.stmt {
+			t.Errorf("Clauses[%v]: got %v, %v, %v; want %v, %v, %v", i, c.Token, c.N, c.Stmt, want.typ, want.n, want.stmt)
+		}
+		if got := strings.TrimSpace(input[c.Pos.Offset:c.End.Offset]); got != want.text {
+			t.Errorf("Clauses[%v]: got text %q, want %q", i, got, want.text)
+		}
+	}
+	if got, want := p.Pos(res.ResolvedStmts[0]), (token.Pos{Offset: 202, Line: 6, Col: 41}); got != want {
+		t.Errorf("Pos(%v): got %+v, want %+v", res.ResolvedStmts[0], got, want)
+	}
+}
+
+func TestCommittee(t *testing.T) {
+	input := `A Resolution Concerning the Tally
+
+WHEREAS the Number of Members (hereinafter Members) is three (3): now, therefore,
+
+BE IT RESOLVED that the Assembly shall resolve itself into a Committee of the Whole, wherein the Running Tally (hereinafter Tally) is Members squared; and
+BE IT FURTHER RESOLVED that the Members Present (hereinafter Members) is Tally; and
+BE IT FURTHER RESOLVED that the Secretary shall publish Members; and
+BE IT FURTHER RESOLVED that the Committee shall rise; and
+BE IT FURTHER RESOLVED that the Secretary shall publish the aforesaid Members.`
+	p := New(lexer.New(input))
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	if len(res.ResolvedStmts) != 2 {
+		t.Fatalf("ParseResolution: got %v Resolved statements, want 2", len(res.ResolvedStmts))
+	}
+	c, ok := res.ResolvedStmts[0].(*ast.CommitteeStmt)
+	if !ok {
+		t.Fatalf("ParseResolution: got %T, want *ast.CommitteeStmt", res.ResolvedStmts[0])
+	}
+	if len(c.Body) != 3 {
+		t.Fatalf("CommitteeStmt: got %v statements, want 3", len(c.Body))
+	}
+	clauses := p.Clauses()
+	for i, want := range []ast.Node{res.WhereasStmts[0], c, c.Body[1], c.Body[2], nil, res.ResolvedStmts[1]} {
+		if clauses[i].Stmt != want {
+			t.Errorf("clause %v: got statement %v, want %v", i+1, clauses[i].Stmt, want)
+		}
+	}
+
+	// A Committee mentioned in commentary is not a Committee of the Whole.
+	input = `A Resolution Concerning the Budget
+
+WHEREAS the Total of Dues (hereinafter Total) is one (1): now, therefore,
+
+BE IT RESOLVED that on the advice of the Finance Committee, Total assume the value two (2); and
+BE IT FURTHER RESOLVED that the Secretary shall publish Total.`
+	res, err = New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	if len(res.ResolvedStmts) != 2 {
+		t.Fatalf("ParseResolution: got %v Resolved statements, want 2", len(res.ResolvedStmts))
+	}
+	if _, ok := res.ResolvedStmts[0].(*ast.AssumeStmt); !ok {
+		t.Errorf("ParseResolution: got %T, want *ast.AssumeStmt", res.ResolvedStmts[0])
+	}
+
+	for _, test := range []struct {
+		input string
+		want  error
+	}{
+		{
+			"title whereas (hereinafter Members) is one (1) resolved publish Members resolved (hereinafter Tally) is two (2)",
+			errResolvedDecl,
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein publish Members resolved the Committee shall rise resolved the Committee shall rise .",
+			errNoCommittee,
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein (hereinafter Tally) is Members resolved publish Tally",
+			errNoRise,
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein (hereinafter Tally) is Members resolved the Committee shall rise resolved publish Tally",
+			undeclaredError{"Tally"},
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein (hereinafter Tally) is Members resolved the Committee shall rise .",
+			unusedError{"Tally"},
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein (hereinafter Tally) is Members resolved (hereinafter Tally) is one (1) resolved publish Tally resolved the Committee shall rise .",
+			redeclaredError{"Tally"},
+		},
+		{
+			"title whereas (hereinafter Members) is one (1) resolved the Assembly shall resolve itself into a Committee of the Whole, wherein if Members exceeds zero (0), (hereinafter Tally) is Members resolved the Committee shall rise .",
+			errConditional,
+		},
+	} {
+		p := New(lexer.New(test.input))
+		p.ParseResolution()
+		found := false
+		for _, err := range p.Errors() {
+			if errors.Unwrap(err) == test.want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("ParseResolution(%v): got %v, want %v", test.input, p.Errors(), test.want)
+		}
+	}
+}
+
+func TestIncorporation(t *testing.T) {
+	input := `A Resolution Concerning the Quorum
+
+WHEREAS the definitions set forth in the resolution "definitions.asm" are hereby incorporated by reference;
+WHEREAS the Members Present (hereinafter Members) is Officers: now, therefore,
+
+BE IT RESOLVED that if Members exceeds Quorum, the Secretary shall publish Greeting.`
+	p := NewFile("testdata/quorum.asm", lexer.New(input))
+	p.SetReadFile(ioutil.ReadFile)
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	s, ok := res.WhereasStmts[0].(*ast.IncorporationStmt)
+	if !ok {
+		t.Fatalf("ParseResolution: got %T, want *ast.IncorporationStmt", res.WhereasStmts[0])
+	}
+	if s.Path != "definitions.asm" || len(s.Stmts) != 3 {
+		t.Errorf("IncorporationStmt: got %v with %v statements, want definitions.asm with 3", s.Path, len(s.Stmts))
+	}
+	// Term is incorporated but not used.
+	for _, id := range []string{"Quorum", "Greeting", "Officers", "Term"} {
+		if p.idents[id] != used {
+			t.Errorf("ParseResolution: %v not declared by incorporation", id)
+		}
+	}
+
+	for _, test := range []struct {
+		input string
+		want  string
+	}{
+		{
+			`title whereas "missing.asm" is incorporated by reference resolved`,
+			"1:15: missing.asm: open testdata/missing.asm: no such file or directory",
+		},
+		{
+			`title whereas "broken.asm" is incorporated by reference resolved`,
+			"1:15: broken.asm: 3:48: cardinal and numeral disagree",
+		},
+		{
+			`title whereas "cycle.asm" is incorporated by reference resolved`,
+			"1:15: cycle.asm: 3:53: standing/../cycle-b.asm: 3:53: incorporation cycle: testdata/cycle.asm incorporates testdata/cycle-b.asm incorporates testdata/cycle.asm",
+		},
+		{
+			`title whereas (hereinafter Quorum) is one (1) whereas "definitions.asm" is incorporated by reference resolved publish Quorum`,
+			"1:55: Quorum redeclared",
+		},
+		{
+			`title whereas the resolution is incorporated by reference resolved`,
+			"1:33: no resolution named for incorporation",
+		},
+	} {
+		p := NewFile("testdata/main.asm", lexer.New(test.input))
+		p.SetReadFile(ioutil.ReadFile)
+		p.ParseResolution()
+		if len(p.Errors()) == 0 || p.Errors()[0].Error() != test.want {
+			t.Errorf("ParseResolution(%v): got %v, want %v", test.input, p.Errors(), test.want)
+		}
+	}
+
+	// Incorporation is an error unless it is enabled.
+	p = NewFile("testdata/quorum.asm", lexer.New(input))
+	p.ParseResolution()
+	if errs := p.Errors(); len(errs) == 0 || errors.Unwrap(errs[0]) != errNoOpen {
+		t.Errorf("ParseResolution without SetReadFile: got %v, want %v", errs, errNoOpen)
+	}
+
+	// Only the phrase "incorporated by reference" incorporates a resolution.
+	input = `A Resolution Concerning the Town
+
+WHEREAS the motto of the Town is "definitions.asm";
+WHEREAS the Town was incorporated in 1850;
+WHEREAS the Population (hereinafter Population) is two (2): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish Population.`
+	p = NewFile("testdata/town.asm", lexer.New(input))
+	p.SetReadFile(func(name string) ([]byte, error) {
+		t.Errorf("ParseResolution: read %v", name)
+		return nil, os.ErrNotExist
+	})
+	if res, err := p.ParseResolution(); err != nil || len(res.WhereasStmts) != 1 {
+		t.Errorf("ParseResolution(%v): got %v, %v; want one declaration", input, res, err)
+	}
+}
+
+func TestRecoverStmt(t *testing.T) {
+	input := `A Resolution Concerning the Average
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish quotient Total Total; and
+BE IT FURTHER RESOLVED that, should the foregoing fail, the error being recorded as Problem, the Secretary shall publish Problem; and
+BE IT FURTHER RESOLVED that, should the foregoing fail, this Assembly shall take no further notice.`
+	p := New(lexer.New(input))
+	res, err := p.ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	if len(res.ResolvedStmts) != 1 {
+		t.Fatalf("ParseResolution: got %v Resolved statements, want 1", len(res.ResolvedStmts))
+	}
+	outer, ok := res.ResolvedStmts[0].(*ast.RecoverStmt)
+	if !ok || outer.Name != nil || outer.Fallback != nil {
+		t.Fatalf("ParseResolution: got %#v, want RecoverStmt without fallback", res.ResolvedStmts[0])
+	}
+	inner, ok := outer.Stmt.(*ast.RecoverStmt)
+	if !ok || inner.Name == nil || inner.Name.Value != "Problem" {
+		t.Fatalf("RecoverStmt: got %#v, want RecoverStmt recording Problem", outer.Stmt)
+	}
+	if _, ok := inner.Stmt.(*ast.PublishStmt); !ok {
+		t.Errorf("RecoverStmt: got %T, want *ast.PublishStmt", inner.Stmt)
+	}
+	clauses := p.Clauses()
+	for i, want := range []ast.Node{res.WhereasStmts[0], inner.Stmt, inner.Fallback, nil} {
+		if clauses[i].Stmt != want {
+			t.Errorf("clause %v: got statement %v, want %v", i+1, clauses[i].Stmt, want)
+		}
+	}
+
+	// A failure mentioned in commentary is not a recovery.
+	input = `A Resolution Concerning the Accounts
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100): now, therefore,
+
+BE IT RESOLVED that, lest our accounts fail, the Secretary shall publish Total.`
+	res, err = New(lexer.New(input)).ParseResolution()
+	if err != nil {
+		t.Fatalf("ParseResolution: unexpected error: %v", err)
+	}
+	if len(res.ResolvedStmts) != 1 {
+		t.Fatalf("ParseResolution: got %v Resolved statements, want 1", len(res.ResolvedStmts))
+	}
+	if _, ok := res.ResolvedStmts[0].(*ast.PublishStmt); !ok {
+		t.Errorf("ParseResolution: got %T, want *ast.PublishStmt", res.ResolvedStmts[0])
+	}
+
+	for _, test := range []struct {
+		input string
+		want  error
+	}{
+		{"title whereas (hereinafter Total) is one (1) resolved should the foregoing fail, publish Total .", errNoForegoing},
+		{"title whereas (hereinafter Total) is one (1) resolved if Total exceeds zero (0), should the foregoing fail, publish Total .", errNoForegoing},
+		{"title whereas (hereinafter Total) is one (1) resolved publish Total resolved should the foregoing fail, the error being recorded as Problem .", unusedError{"Problem"}},
+		{"title whereas (hereinafter Total) is one (1) resolved publish Total resolved should the foregoing fail, the error being recorded as publish Total .", errNoRecorded},
+		{"title whereas (hereinafter Total) is one (1) resolved publish Total resolved should the foregoing fail, the error being recorded as Problem, publish Problem resolved publish Problem", undeclaredError{"Problem"}},
+	} {
+		p := New(lexer.New(test.input))
+		p.ParseResolution()
+		found := false
+		for _, err := range p.Errors() {
+			if errors.Unwrap(err) == test.want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("ParseResolution(%v): got %v, want %v", test.input, p.Errors(), test.want)
+		}
+	}
+}
@@ -0,0 +1,3 @@
+A Resolution With an Error
+
+WHEREAS the Total (hereinafter Total) is two (3).
@@ -0,0 +1,3 @@
+A Resolution That Incorporates Another
+
+WHEREAS the definitions set forth in the resolution "cycle.asm" are hereby incorporated by reference.
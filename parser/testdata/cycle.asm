@@ -0,0 +1,3 @@
+A Resolution That Incorporates Itself
+
+WHEREAS the definitions set forth in the resolution "standing/../cycle-b.asm" are hereby incorporated by reference.
@@ -0,0 +1,5 @@
+Standing Definitions of the Assembly
+
+WHEREAS the Required Quorum (hereinafter Quorum) is three (3);
+WHEREAS the Customary Greeting (hereinafter Greeting) is "Hello, World!";
+WHEREAS the definitions set forth in the resolution "standing/officers.asm" are hereby incorporated by reference.
@@ -0,0 +1,4 @@
+Definitions Concerning Officers
+
+WHEREAS the Number of Officers (hereinafter Officers) is two (2);
+WHEREAS the Term of Office (hereinafter Term) is two (2).
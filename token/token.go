@@ -1,6 +1,11 @@
 package token
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // Token is a lexical token of Assembly source code.
 type Token struct {
@@ -8,6 +13,26 @@ type Token struct {
 	Lit string
 }
 
+// Pos is a position in Assembly source code.
+// Offset is a byte offset, starting at 0; Line and Col are each counted starting at 1.
+// Col counts characters rather than bytes.
+// The zero value is not a valid position.
+type Pos struct {
+	Offset    int
+	Line, Col int
+}
+
+// IsValid reports whether pos represents a position in source code.
+func (pos Pos) IsValid() bool { return pos.Line > 0 }
+
+// Pos implements the fmt.Stringer interface.
+func (pos Pos) String() string {
+	if !pos.IsValid() {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%d", pos.Line, pos.Col)
+}
+
 // Type is a token type.
 type Type int
 
@@ -42,9 +67,33 @@ const (
 	REMAINDER
 	LESS
 
+	// Numeric operators synthesized by the parser from phrases such as "absolute value of"
+	RAISED
+	ABSOLUTE
+	FACTORIAL
+	GREATER
+	LESSER
+	GREATEST
+
 	// Relational operators
 	EQUALS
 	EXCEEDS
+	PRECEDES
+
+	// Relational operators synthesized by the parser from phrases such as "does not equal"
+	NOTEQUAL
+	ATLEAST
+	ATMOST
+	LESSTHAN
+
+	// Keywords synthesized by the parser from words that are keywords only in context,
+	// such as "finding" in "the finding of the Audit"
+	FINDING
+	COMMITTEE
+	INCORPORATED
+	FAIL
+	ENTER
+	REPORT
 
 	// Punctuation
 	LPAREN
@@ -58,6 +107,13 @@ const (
 	ASSUME
 	IF
 	PUBLISH
+	AFFIRMS
+
+	// Publish formats synthesized by the parser from phrases such as "in figures"
+	WORDS
+	FIGURES
+	ORDINAL
+	ROMAN
 )
 
 var keywords = map[string]Type{
@@ -93,7 +149,9 @@ var keywords = map[string]Type{
 	"hundred":     HUNDRED,
 	"thousand":    POWER,
 	"million":     POWER,
+	"milliard":    POWER,
 	"billion":     POWER,
+	"billiard":    POWER,
 	"trillion":    POWER,
 	"quadrillion": POWER,
 	"quintillion": POWER,
@@ -108,8 +166,9 @@ var keywords = map[string]Type{
 	"remainder": REMAINDER,
 	"less":      LESS,
 
-	"equals":  EQUALS,
-	"exceeds": EXCEEDS,
+	"equals":   EQUALS,
+	"exceeds":  EXCEEDS,
+	"precedes": PRECEDES,
 
 	"whereas":     WHEREAS,
 	"resolved":    RESOLVED,
@@ -117,22 +176,43 @@ var keywords = map[string]Type{
 	"assume":      ASSUME,
 	"if":          IF,
 	"publish":     PUBLISH,
+	"affirms":     AFFIRMS,
 }
 
 // Lookup maps s to its keyword Type, if any,
-// or else to IDENT if it begins with a capital letter
+// or else to IDENT if it begins with an uppercase letter in any script
 // or COMMENT otherwise.
 func Lookup(s string) Type {
 	lower := strings.ToLower(s)
 	if typ, ok := keywords[lower]; ok {
 		return typ
 	}
-	if 'A' <= s[0] && s[0] <= 'Z' {
+	if r, _ := utf8.DecodeRuneInString(s); unicode.IsUpper(r) {
 		return IDENT
 	}
 	return COMMENT
 }
 
+// IsIdentifier reports whether s is a valid identifier: one or more capitalized words separated by single spaces,
+// such as "Capital Improvement Fund", none of which is a keyword.
+// Each word consists of letters and may end with a possessive 's. A capitalized keyword that follows a possessive
+// is taken as a word, as in "Treasurer's Remainder".
+func IsIdentifier(s string) bool {
+	possessive := false
+	for _, w := range strings.Split(s, " ") {
+		if r, _ := utf8.DecodeRuneInString(w); !unicode.IsUpper(r) || !possessive && Lookup(w) != IDENT {
+			return false
+		}
+		for _, r := range strings.TrimSuffix(w, "'s") {
+			if !unicode.IsLetter(r) {
+				return false
+			}
+		}
+		possessive = strings.HasSuffix(w, "'s")
+	}
+	return true
+}
+
 // IsCardinal reports whether t's Type is one of the cardinal numeric Types.
 func (t Token) IsCardinal() bool {
 	return t.Typ == NEGATIVE ||
@@ -0,0 +1,17 @@
+A Resolution Concerning Suspicious Things
+
+WHEREAS the Total of Dues (hereinafter Total) is one hundred (100); and
+WHEREAS; and
+WHEREAS the members have gathered in good faith; and
+WHEREAS the Count of Members (hereinafter Count) is four (4): now, therefore,
+
+BE IT RESOLVED that if one (1) exceeds two (2), the Secretary shall publish Total; and
+BE IT FURTHER RESOLVED that Count assume the value Count; and
+BE IT FURTHER RESOLVED that Total assume the value sum Total Count; and
+BE IT FURTHER RESOLVED that Total assume the value twice Count; and
+BE IT FURTHER RESOLVED that the Secretary shall pubilsh Total; and
+BE IT FURTHER RESOLVED that the Assembly shall resolve itself into a Committee of the Whole, wherein the Running Tally (hereinafter Tally) is Count squared; and
+BE IT FURTHER RESOLVED that Tally assume the value sum Tally Count; and
+BE IT FURTHER RESOLVED that the Committee shall rise and report; and
+BE IT FURTHER RESOLVED that the Secretary shall publish quotient Count Count; and
+BE IT FURTHER RESOLVED that, should the foregoing fail, this Assembly shall take no further notice.
@@ -0,0 +1,173 @@
+/*
+Package vet reports suspicious constructs in Assembly resolutions that are nevertheless legal,
+such as an if statement whose condition compares two constants
+or a Resolved clause that contains no statement that the parser recognizes.
+*/
+package vet
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dkmccandless/assembly/ast"
+	"github.com/dkmccandless/assembly/lexer"
+	"github.com/dkmccandless/assembly/object"
+	"github.com/dkmccandless/assembly/parser"
+	"github.com/dkmccandless/assembly/token"
+)
+
+// A Warning describes a suspicious construct.
+type Warning struct {
+	Pos token.Pos
+
+	// Check names the check that reported the Warning, such as "self-assumption".
+	Check string
+
+	Message string
+}
+
+// String returns a description of w such as "5:21: Count assumes its own value".
+func (w Warning) String() string { return fmt.Sprintf("%v: %v", w.Pos, w.Message) }
+
+// Options configure the parsing of a resolution.
+type Options struct {
+	// Dialect holds the conventions by which the resolution's integer literals are written.
+	// The zero Dialect is object.American.
+	Dialect object.Dialect
+
+	// Lenient, if set, accepts common nonstandard forms of integers,
+	// which Check reports as Warnings instead of as errors.
+	Lenient bool
+}
+
+// Check parses the resolution in src, read from the file filename, and returns Warnings describing
+// its suspicious constructs in order of position. If src cannot be parsed, Check returns the parser's errors.
+func Check(filename, src string, opts Options) ([]Warning, error) {
+	p := parser.NewFile(filename, lexer.New(src))
+	p.SetReadFile(ioutil.ReadFile)
+	p.SetDialect(opts.Dialect)
+	p.SetLenient(opts.Lenient)
+	res, err := p.ParseResolution()
+	if err != nil {
+		return nil, err
+	}
+	var ws []Warning
+	for _, w := range p.Warnings() {
+		ws = append(ws, Warning{w.Pos, "nonstandard-integer", fmt.Sprintf("%v; write %q", w.Message, w.Suggestion)})
+	}
+	for _, c := range p.Clauses() {
+		switch {
+		case c.Stmt != nil:
+		case c.Token.Typ == token.WHEREAS && isEmpty(c.Text(src)):
+			ws = append(ws, Warning{c.Pos, "empty-whereas", fmt.Sprintf("%v has neither a declaration nor any commentary", c)})
+		case c.Token.Typ == token.RESOLVED && !hasStmtPhrase(c.Text(src)):
+			ws = append(ws, Warning{c.Pos, "no-statement", fmt.Sprintf("%v contains no recognized statement", c)})
+		}
+	}
+	ast.Inspect(res, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IncorporationStmt:
+			// Incorporated declarations are vetted with the resolution that contains them.
+			return false
+		case *ast.IfStmt:
+			if isConstant(n.Left) && isConstant(n.Right) {
+				ws = append(ws, Warning{p.Pos(n), "constant-condition",
+					fmt.Sprintf("condition %v %v %v compares two constants", n.Left, n.Relation.Lit, n.Right)})
+			}
+		case *ast.AffirmStmt:
+			if isConstant(n.Left) && isConstant(n.Right) {
+				ws = append(ws, Warning{p.Pos(n), "constant-condition",
+					fmt.Sprintf("affirmation %v %v %v compares two constants", n.Left, n.Relation.Lit, n.Right)})
+			}
+		case *ast.AssumeStmt:
+			if id, ok := n.Value.(*ast.Identifier); ok && id.Value == n.Name.Value {
+				ws = append(ws, Warning{p.Pos(n), "self-assumption", fmt.Sprintf("%v assumes its own value", n.Name)})
+			}
+		}
+		return true
+	})
+	for _, s := range p.Unread() {
+		ws = append(ws, Warning{p.Pos(s), "unread-assumption", fmt.Sprintf("value assumed by %v is never read", s.Name)})
+	}
+	sort.SliceStable(ws, func(i, j int) bool { return ws[i].Pos.Offset < ws[j].Pos.Offset })
+	return ws, nil
+}
+
+// isConstant reports whether the value of e is independent of the resolution's variables and findings.
+func isConstant(e ast.Expr) bool {
+	constant := true
+	ast.Inspect(e, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.Identifier, *ast.CallExpr:
+			constant = false
+		}
+		return constant
+	})
+	return constant
+}
+
+// isEmpty reports whether the text of a Whereas clause consists only of its keyword
+// and the punctuation and words that join it to the following clause.
+func isEmpty(text string) bool {
+	l := lexer.New(text)
+	for {
+		t, err := l.Next()
+		if err != nil {
+			return false
+		}
+		switch {
+		case t.Typ == token.EOF:
+			return true
+		case t.Typ == token.WHEREAS, t.Typ != token.STRING && joiners[strings.ToLower(t.Lit)], t.Typ == token.COMMENT && !isWord(t.Lit):
+		default:
+			return false
+		}
+	}
+}
+
+// isWord reports whether lit, the literal of a token.COMMENT, is a word rather than punctuation.
+func isWord(lit string) bool {
+	r, _ := utf8.DecodeRuneInString(lit)
+	return unicode.IsLetter(r)
+}
+
+// joiners holds the words, in lowercase, that may join a Whereas clause to the clause that follows it.
+var joiners = map[string]bool{"and": true, "now": true, "therefore": true}
+
+// stmtPhrases holds the phrases, in lowercase, that form part of a statement without one of its own,
+// such as the rising of a Committee of the Whole or a recovery clause that disregards a failure.
+var stmtPhrases = []string{"committee shall rise", "should the foregoing fail"}
+
+// hasStmtPhrase reports whether the text of a Resolved clause contains a phrase
+// that forms part of a statement without one of its own, such as the rising of a Committee of the Whole
+// or a recovery clause that disregards a failure.
+func hasStmtPhrase(text string) bool {
+	var words []string
+	l := lexer.New(text)
+	for {
+		t, err := l.Next()
+		if err != nil {
+			return false
+		}
+		if t.Typ == token.EOF {
+			break
+		}
+		if t.Typ == token.STRING {
+			// A string literal never forms part of a phrase.
+			words = append(words, `""`)
+			continue
+		}
+		words = append(words, strings.ToLower(t.Lit))
+	}
+	joined := " " + strings.Join(words, " ") + " "
+	for _, phrase := range stmtPhrases {
+		if strings.Contains(joined, " "+phrase+" ") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package vet
+
+import (
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"github.com/dkmccandless/assembly/object"
+)
+
+func TestCheck(t *testing.T) {
+	b, err := ioutil.ReadFile("testdata/suspicious.asm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ws, err := Check("testdata/suspicious.asm", string(b), Options{})
+	if err != nil {
+		t.Fatalf("Check: unexpected error: %v", err)
+	}
+	var got []string
+	for _, w := range ws {
+		got = append(got, w.Check+" "+w.String())
+	}
+	want := []string{
+		"empty-whereas 4:1: Whereas clause 2 has neither a declaration nor any commentary",
+		"constant-condition 8:21: condition 1 exceeds 2 compares two constants",
+		"self-assumption 9:29: Count assumes its own value",
+		"unread-assumption 10:29: value assumed by Total is never read",
+		"unread-assumption 11:29: value assumed by Total is never read",
+		"no-statement 12:15: Resolved clause 5 contains no recognized statement",
+		"unread-assumption 14:29: value assumed by Tally is never read",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check: got\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCheckClean(t *testing.T) {
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the members have gathered in good faith; and
+WHEREAS the Count of Members (hereinafter Count) is two (2): now, therefore,
+
+BE IT RESOLVED that if Count exceeds one (1), Count assume the value sum Count one (1); and
+BE IT FURTHER RESOLVED that the Assembly affirms that Count exceeds zero (0); and
+BE IT FURTHER RESOLVED that the Secretary shall publish the aforesaid Count.`
+	ws, err := Check("", src, Options{})
+	if err != nil || len(ws) != 0 {
+		t.Errorf("Check: got %v, %v; want no warnings", ws, err)
+	}
+}
+
+func TestCheckConditionalAssumption(t *testing.T) {
+	const src = `A Resolution Concerning the Total
+
+WHEREAS the Total of Dues (hereinafter Total) is zero (0);
+WHEREAS the Count of Members (hereinafter Count) is three (3): now, therefore,
+
+BE IT RESOLVED that Total assume the value five (5); and
+BE IT FURTHER RESOLVED that if Count exceeds ten (10), Total assume the value ten (10); and
+BE IT FURTHER RESOLVED that the Secretary shall publish Total.`
+	ws, err := Check("", src, Options{})
+	if err != nil || len(ws) != 0 {
+		t.Errorf("Check: got %v, %v; want no warnings", ws, err)
+	}
+}
+
+func TestCheckParseError(t *testing.T) {
+	ws, err := Check("", "A Resolution\n\nWHEREAS the Count (hereinafter Count) is two (2)", Options{})
+	if err == nil || ws != nil {
+		t.Errorf("Check: got %v, %v; want error", ws, err)
+	}
+}
+
+func TestCheckOptions(t *testing.T) {
+	const src = `A Resolution Concerning the Count
+
+WHEREAS the Count of Members (hereinafter Count) is one hundred and one (101): now, therefore,
+
+BE IT RESOLVED that the Secretary shall publish the sum of Count and twenty one (21).`
+	if _, err := Check("", src, Options{}); err == nil {
+		t.Errorf("Check: got no error in American usage")
+	}
+	ws, err := Check("", src, Options{Dialect: object.British, Lenient: true})
+	if err != nil {
+		t.Fatalf("Check: unexpected error: %v", err)
+	}
+	var got []string
+	for _, w := range ws {
+		got = append(got, w.Check+" "+w.String())
+	}
+	want := []string{
+		`nonstandard-integer 5:70: nonstandard integer: "twenty one" without a hyphen; write "twenty-one (21)"`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Check: got\n%q\nwant\n%q", got, want)
+	}
+}